@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/rrdr20/smbtest/smbios"
+)
+
+var memoryCSVHeader = []string{"locator", "bank_locator", "size_mb", "speed", "type", "manufacturer", "part_number",
+	"serial_number"}
+
+// renderMemoryCSV writes one row per Type 17 memory device, empty slots included with a size of 0
+func renderMemoryCSV(w io.Writer, t *smbios.SmTable) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(memoryCSVHeader); err != nil {
+		return err
+	}
+
+	for _, s := range t.ByType(17) {
+		md, err := s.MemoryDevice()
+		if err != nil {
+			return err
+		}
+
+		size := strconv.FormatUint(md.SizeMB(), 10)
+		if md.SizeUnknown {
+			size = "unknown"
+		}
+
+		row := []string{
+			md.DeviceLocator,
+			md.BankLocator,
+			size,
+			strconv.Itoa(int(md.Speed)),
+			md.Type.String(),
+			md.Manufacturer,
+			md.PartNumber,
+			md.SerialNumber,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/rrdr20/smbtest/smbios"
+)
+
+// diffStructure is a structure as read back from the json format, the decoded fields stay generic so any type can be
+// compared
+type diffStructure struct {
+	Header    smbios.Header `json:"header"`
+	Decoded   any           `json:"decoded"`
+	Formatted []byte        `json:"formatted"`
+	Strings   []string      `json:"strings"`
+}
+
+// diffKey identifies a structure across the two inventories
+type diffKey struct {
+	typ    uint8
+	handle uint16
+}
+
+// loadInventory reads a file written by the json format, two structures with the same type and handle cannot be told
+// apart so they are an error
+func loadInventory(path string) (map[diffKey]diffStructure, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var inv struct {
+		Structures []diffStructure `json:"structures"`
+	}
+	if err := json.Unmarshal(b, &inv); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	ss := map[diffKey]diffStructure{}
+	for _, s := range inv.Structures {
+		k := diffKey{s.Header.Type, s.Header.Handle}
+		if _, ok := ss[k]; ok {
+			return nil, fmt.Errorf("%s: more than one type %d structure with handle 0x%04X", path, k.typ, k.handle)
+		}
+		ss[k] = s
+	}
+
+	return ss, nil
+}
+
+// fields flattens the structure into one value per field, nested fields are joined with a dot and list entries are
+// indexed
+func (s diffStructure) fields() map[string]string {
+	fs := map[string]string{}
+	// Most decoders return a struct whose fields are named as they are, the string list types are named decoded
+	switch d := s.Decoded.(type) {
+	case nil:
+	case map[string]any:
+		flattenField(fs, "", d)
+	default:
+		flattenField(fs, "decoded", d)
+	}
+	if s.Formatted != nil {
+		fs["formatted"] = fmt.Sprintf("%X", s.Formatted)
+	}
+	for i, str := range s.Strings {
+		fs["strings["+strconv.Itoa(i)+"]"] = strconv.Quote(str)
+	}
+
+	return fs
+}
+
+func flattenField(fs map[string]string, name string, v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if name != "" {
+				k = name + "." + k
+			}
+			flattenField(fs, k, e)
+		}
+	case []any:
+		for i, e := range v {
+			flattenField(fs, name+"["+strconv.Itoa(i)+"]", e)
+		}
+	default:
+		b, _ := json.Marshal(v)
+		fs[name] = string(b)
+	}
+}
+
+// diffInventories writes one line per added, removed or changed structure and one per changed field, in type and
+// handle order, and reports whether the two inventories match
+func diffInventories(w io.Writer, a, b map[diffKey]diffStructure) bool {
+	keys := []diffKey{}
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].typ != keys[j].typ {
+			return keys[i].typ < keys[j].typ
+		}
+		return keys[i].handle < keys[j].handle
+	})
+
+	same := true
+	for _, k := range keys {
+		prefix := fmt.Sprintf("Handle 0x%04X, DMI type %d", k.handle, k.typ)
+		as, inA := a[k]
+		bs, inB := b[k]
+
+		switch {
+		case !inA:
+			fmt.Fprintf(w, "%s: added %s\n", prefix, bs.Header.TypeName())
+			same = false
+			continue
+		case !inB:
+			fmt.Fprintf(w, "%s: removed %s\n", prefix, as.Header.TypeName())
+			same = false
+			continue
+		}
+
+		changes := []string{}
+		if as.Header.Length != bs.Header.Length {
+			changes = append(changes, fmt.Sprintf("length %d -> %d", as.Header.Length, bs.Header.Length))
+		}
+
+		af, bf := as.fields(), bs.fields()
+		names := []string{}
+		for n := range af {
+			names = append(names, n)
+		}
+		for n := range bf {
+			if _, ok := af[n]; !ok {
+				names = append(names, n)
+			}
+		}
+		sort.Strings(names)
+
+		for _, n := range names {
+			av, inA := af[n]
+			bv, inB := bf[n]
+			switch {
+			case !inA:
+				changes = append(changes, fmt.Sprintf("%s added %s", n, bv))
+			case !inB:
+				changes = append(changes, fmt.Sprintf("%s removed %s", n, av))
+			case av != bv:
+				changes = append(changes, fmt.Sprintf("%s %s -> %s", n, av, bv))
+			}
+		}
+
+		if len(changes) > 0 {
+			same = false
+			fmt.Fprintf(w, "%s: changed %s\n", prefix, as.Header.TypeName())
+			for _, c := range changes {
+				fmt.Fprintf(w, "%s: %s\n", prefix, c)
+			}
+		}
+	}
+
+	return same
+}
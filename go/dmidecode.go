@@ -0,0 +1,466 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/rrdr20/smbtest/smbios"
+)
+
+// Section titles as printed by dmidecode, anything missing falls back to the spec name
+var dmidecodeNames = map[uint8]string{
+	2:   "Base Board Information",
+	3:   "Chassis Information",
+	9:   "System Slot Information",
+	127: "End Of Table",
+}
+
+// renderDmidecode writes the table in the layout used by dmidecode so existing scripts can parse it
+func renderDmidecode(w io.Writer, t *smbios.SmTable) error {
+	if ep := t.EntryPoint; ep != nil {
+		fmt.Fprintf(w, "SMBIOS %s present.\n\n", ep.Version())
+	}
+
+	for _, s := range t.Structures {
+		dmidecodeStructure(w, s)
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
+
+// dmidecodeStructure writes a single structure, decoded when there is a decoder for its type
+func dmidecodeStructure(w io.Writer, s smbios.Structure) {
+	fmt.Fprintf(w, "Handle 0x%04X, DMI type %d, %d bytes\n", s.Header.Handle, s.Header.Type, s.Header.Length)
+
+	name, ok := dmidecodeNames[s.Header.Type]
+	if !ok {
+		name = s.Header.TypeName()
+	}
+	fmt.Fprintln(w, name)
+
+	// Types without a dmidecode layout print the fields of their typed decoder, only structures without one or that
+	// fail to decode are dumped raw
+	err := dmidecodeFields(w, s)
+	if errors.Is(err, errNoDecoder) {
+		v, derr := s.Decode()
+		if _, undecoded := v.(smbios.Undecoded); derr == nil && !undecoded {
+			dmidecodeDecoded(w, v)
+			return
+		}
+	}
+	if err != nil {
+		dmidecodeRaw(w, s)
+	}
+}
+
+// dmidecodeDecoded writes the exported fields of a value returned by Decode, one per line with the field name split
+// into words
+func dmidecodeDecoded(w io.Writer, v any) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+
+	// The string list types, such as Type 12, number their entries the way Type 11 does
+	if rv.Kind() == reflect.Slice {
+		for i := 0; i < rv.Len(); i++ {
+			fmt.Fprintf(w, "\tString %d: %v\n", i+1, rv.Index(i))
+		}
+		return
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		if sf := rt.Field(i); sf.IsExported() {
+			fmt.Fprintf(w, "\t%s: %s\n", fieldTitle(sf.Name), dmidecodeValue(sf.Name, rv.Field(i)))
+		}
+	}
+}
+
+// dmidecodeValue formats one decoded field, handles and addresses in hex and unknown values as dmidecode does
+func dmidecodeValue(name string, v reflect.Value) string {
+	switch {
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return "Unknown"
+		}
+		return dmidecodeValue(name, v.Elem())
+	case v.Kind() == reflect.String:
+		return notSpecified(v.String())
+	case v.Kind() == reflect.Slice && v.Len() == 0:
+		return "None"
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8:
+		ss := make([]string, v.Len())
+		for i := range ss {
+			ss[i] = dmidecodeValue(name, v.Index(i))
+		}
+		return strings.Join(ss, ", ")
+	case v.Kind() == reflect.Slice:
+		return hexBytes(v.Bytes())
+	case v.Kind() == reflect.Uint16 && (strings.HasSuffix(name, "Handle") || strings.HasSuffix(name, "Handles")):
+		return handle(uint16(v.Uint()))
+	case v.CanUint() && strings.HasSuffix(name, "Address"):
+		return fmt.Sprintf("0x%X", v.Uint())
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// fieldTitle splits a Go field name into words, keeping acronyms together, so ErrorInfoHandle reads Error Info Handle
+// and OEMDefined reads OEM Defined
+func fieldTitle(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			prev, next := rune(name[i-1]), rune(0)
+			if i+1 < len(name) {
+				next = rune(name[i+1])
+			}
+			if !unicode.IsUpper(prev) || unicode.IsLower(next) {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// errNoDecoder marks a structure type without a typed decoder
+var errNoDecoder = errors.New("no decoder")
+
+func dmidecodeFields(w io.Writer, s smbios.Structure) error {
+	f := func(name string, v any) {
+		fmt.Fprintf(w, "\t%s: %v\n", name, v)
+	}
+
+	switch s.Header.Type {
+	case 0:
+		bi, err := s.BIOS()
+		if err != nil {
+			return err
+		}
+		f("Vendor", notSpecified(bi.Vendor))
+		f("Version", notSpecified(bi.Version))
+		f("Release Date", notSpecified(bi.ReleaseDate))
+		f("Address", fmt.Sprintf("0x%04X0", bi.StartingSegment))
+		f("Runtime Size", formatSize((0x10000-uint64(bi.StartingSegment))<<4))
+		f("ROM Size", formatSize(bi.ROMSize))
+		fmt.Fprintln(w, "\tCharacteristics:")
+		for _, c := range bi.CharacteristicsSet() {
+			fmt.Fprintf(w, "\t\t%s\n", c)
+		}
+		if bi.BIOSMajor != 0xFF {
+			f("BIOS Revision", fmt.Sprintf("%d.%d", bi.BIOSMajor, bi.BIOSMinor))
+		}
+		if bi.FirmwareMajor != 0xFF {
+			f("Firmware Revision", fmt.Sprintf("%d.%d", bi.FirmwareMajor, bi.FirmwareMinor))
+		}
+	case 1:
+		si, err := s.System()
+		if err != nil {
+			return err
+		}
+		f("Manufacturer", notSpecified(si.Manufacturer))
+		f("Product Name", notSpecified(si.ProductName))
+		f("Version", notSpecified(si.Version))
+		f("Serial Number", notSpecified(si.SerialNumber))
+		f("UUID", notPresent(si.UUID))
+		f("Wake-up Type", si.WakeUpType)
+		f("SKU Number", notSpecified(si.SKUNumber))
+		f("Family", notSpecified(si.Family))
+	case 2:
+		bb, err := s.Baseboard()
+		if err != nil {
+			return err
+		}
+		f("Manufacturer", notSpecified(bb.Manufacturer))
+		f("Product Name", notSpecified(bb.Product))
+		f("Version", notSpecified(bb.Version))
+		f("Serial Number", notSpecified(bb.SerialNumber))
+		f("Asset Tag", notSpecified(bb.AssetTag))
+		f("Features", fmt.Sprintf("0x%02X", bb.FeatureFlags))
+		f("Location In Chassis", notSpecified(bb.LocationInChassis))
+		f("Chassis Handle", fmt.Sprintf("0x%04X", bb.ChassisHandle))
+		f("Type", bb.BoardType)
+		f("Contained Object Handles", len(bb.ObjectHandles))
+	case 3:
+		ci, err := s.Chassis()
+		if err != nil {
+			return err
+		}
+		f("Manufacturer", notSpecified(ci.Manufacturer))
+		f("Type", ci.Type)
+		f("Lock", map[bool]string{true: "Present", false: "Not Present"}[ci.Lock])
+		f("Version", notSpecified(ci.Version))
+		f("Serial Number", notSpecified(ci.SerialNumber))
+		f("Asset Tag", notSpecified(ci.AssetTag))
+		f("Boot-up State", ci.BootUpState)
+		f("Power Supply State", ci.PowerSupplyState)
+		f("Thermal State", ci.ThermalState)
+		f("Security Status", ci.SecurityStatus)
+		f("OEM Information", fmt.Sprintf("0x%08X", ci.OEMDefined))
+		if ci.Height == 0 {
+			f("Height", "Unspecified")
+		} else {
+			f("Height", fmt.Sprintf("%d U", ci.Height))
+		}
+		f("Number Of Power Cords", ci.NumberPowerCords)
+		f("Contained Elements", len(ci.ContainedElements))
+		f("SKU Number", notSpecified(ci.SKUNumber))
+	case 4:
+		pi, err := s.Processor()
+		if err != nil {
+			return err
+		}
+		f("Socket Designation", notSpecified(pi.SocketDesignation))
+		f("Type", pi.ProcessorType)
+		f("Family", pi.Family)
+		f("Manufacturer", notSpecified(pi.Manufacturer))
+		f("ID", hexBytes(le64(pi.ID)))
+		f("Version", notSpecified(pi.Version))
+		f("External Clock", mhz(pi.ExternalClock))
+		f("Max Speed", mhz(pi.MaxSpeed))
+		f("Current Speed", mhz(pi.CurrentSpeed))
+		f("Status", fmt.Sprintf("0x%02X", pi.Status))
+		f("Upgrade", pi.Upgrade)
+		f("L1 Cache Handle", fmt.Sprintf("0x%04X", pi.L1CacheHandle))
+		f("L2 Cache Handle", fmt.Sprintf("0x%04X", pi.L2CacheHandle))
+		f("L3 Cache Handle", fmt.Sprintf("0x%04X", pi.L3CacheHandle))
+		f("Serial Number", notSpecified(pi.SerialNumber))
+		f("Asset Tag", notSpecified(pi.AssetTag))
+		f("Part Number", notSpecified(pi.PartNumber))
+		f("Core Count", pi.CoreCount)
+		f("Core Enabled", pi.CoreEnabled)
+		f("Thread Count", pi.ThreadCount)
+	case 7:
+		ci, err := s.Cache()
+		if err != nil {
+			return err
+		}
+		f("Socket Designation", notSpecified(ci.SocketDesignation))
+		f("Configuration", fmt.Sprintf("%s, %s, Level %d",
+			map[bool]string{true: "Enabled", false: "Disabled"}[ci.Enabled],
+			map[bool]string{true: "Socketed", false: "Not Socketed"}[ci.Socketed],
+			ci.Level))
+		f("Operational Mode", ci.OperationalMode)
+		f("Location", ci.Location)
+		f("Installed Size", formatSize(ci.InstalledSize))
+		f("Maximum Size", formatSize(ci.MaximumSize))
+		f("Speed", ci.Speed)
+		f("Error Correction Type", ci.ErrorCorrectionType)
+		f("System Type", ci.SystemCacheType)
+		f("Associativity", ci.Associativity)
+	case 9:
+		ss, err := s.SystemSlot()
+		if err != nil {
+			return err
+		}
+		f("Designation", notSpecified(ss.Designation))
+		f("Type", ss.Type)
+		f("Current Usage", ss.CurrentUsage)
+		f("Length", ss.Length)
+		f("ID", ss.ID)
+		f("Bus Address", fmt.Sprintf("%04x:%02x:%02x.%x", ss.SegmentGroup, ss.BusNumber, ss.DeviceNumber, ss.FunctionNumber))
+	case 11:
+		oem, err := s.OEMStrings()
+		if err != nil {
+			return err
+		}
+		for i, v := range oem {
+			f(fmt.Sprintf("String %d", i+1), v)
+		}
+	case 16:
+		pa, err := s.PhysicalMemoryArray()
+		if err != nil {
+			return err
+		}
+		f("Location", pa.Location)
+		f("Use", pa.Use)
+		f("Error Correction Type", pa.ErrorCorrection)
+		f("Maximum Capacity", formatSize(pa.MaximumCapacity))
+		f("Error Information Handle", errorInfoHandle(pa.ErrorInfoHandle))
+		f("Number Of Devices", pa.NumberDevices)
+	case 17:
+		md, err := s.MemoryDevice()
+		if err != nil {
+			return err
+		}
+		f("Array Handle", fmt.Sprintf("0x%04X", md.PhysicalArrayHandle))
+		f("Error Information Handle", errorInfoHandle(md.ErrorInfoHandle))
+		f("Total Width", bits(md.TotalWidth))
+		f("Data Width", bits(md.DataWidth))
+		switch {
+		case md.SizeUnknown:
+			f("Size", "Unknown")
+		case md.Size == 0:
+			f("Size", "No Module Installed")
+		default:
+			f("Size", formatSize(md.Size))
+		}
+		f("Form Factor", md.FormFactor)
+		f("Set", md.DeviceSet)
+		f("Locator", notSpecified(md.DeviceLocator))
+		f("Bank Locator", notSpecified(md.BankLocator))
+		f("Type", md.Type)
+		f("Type Detail", fmt.Sprintf("0x%04X", md.TypeDetail))
+		f("Speed", mts(md.Speed))
+		f("Manufacturer", notSpecified(md.Manufacturer))
+		f("Serial Number", notSpecified(md.SerialNumber))
+		f("Asset Tag", notSpecified(md.AssetTag))
+		f("Part Number", notSpecified(md.PartNumber))
+		f("Configured Memory Speed", mts(md.ConfiguredSpeed))
+	case 38:
+		ipmi, err := s.IPMI()
+		if err != nil {
+			return err
+		}
+		f("Interface Type", ipmi.InterfaceType)
+		f("Specification Version", fmt.Sprintf("%d.%d", ipmi.SpecMajor, ipmi.SpecMinor))
+		f("I2C Slave Address", fmt.Sprintf("0x%02x", ipmi.I2CSlaveAddress>>1))
+		if ipmi.NVStorageAddress == 0xFF {
+			f("NV Storage Device", "Not Present")
+		} else {
+			f("NV Storage Device Address", ipmi.NVStorageAddress)
+		}
+		f("Base Address", fmt.Sprintf("0x%016X (%s)", ipmi.BaseAddress,
+			map[bool]string{true: "I/O", false: "Memory-mapped"}[ipmi.IOSpace]))
+		if ipmi.RegisterSpacing != 0 {
+			f("Register Spacing", fmt.Sprintf("%d-byte Boundaries", ipmi.RegisterSpacing))
+		}
+		if ipmi.InterruptSpecified {
+			f("Interrupt Number", ipmi.InterruptNumber)
+		}
+	case 43:
+		tpm, err := s.TPM()
+		if err != nil {
+			return err
+		}
+		f("Vendor ID", tpm.VendorID)
+		f("Specification Version", fmt.Sprintf("%d.%d", tpm.MajorSpecVersion, tpm.MinorSpecVersion))
+		f("Firmware Revision", fmt.Sprintf("0x%08X 0x%08X", tpm.FirmwareVersion1, tpm.FirmwareVersion2))
+		f("Description", notSpecified(tpm.Description))
+		f("Characteristics", fmt.Sprintf("0x%016X", tpm.Characteristics))
+		f("OEM-specific Information", fmt.Sprintf("0x%08X", tpm.OEMDefined))
+	case 127:
+	default:
+		return errNoDecoder
+	}
+
+	return nil
+}
+
+// dmidecodeRaw prints the structure bytes, header included, and the string table
+func dmidecodeRaw(w io.Writer, s smbios.Structure) {
+	b := append([]byte{s.Header.Type, s.Header.Length, byte(s.Header.Handle), byte(s.Header.Handle >> 8)}, s.Formatterd...)
+
+	fmt.Fprintln(w, "\tHeader and Data:")
+	for i := 0; i < len(b); i += 16 {
+		end := i + 16
+		if end > len(b) {
+			end = len(b)
+		}
+		fmt.Fprintf(w, "\t\t%s\n", hexBytes(b[i:end]))
+	}
+
+	if len(s.Strings) > 0 {
+		fmt.Fprintln(w, "\tStrings:")
+		for _, v := range s.Strings {
+			fmt.Fprintf(w, "\t\t%s\n", v)
+		}
+	}
+}
+
+func notSpecified(v string) string {
+	if v == "" {
+		return "Not Specified"
+	}
+
+	return v
+}
+
+func notPresent(v string) string {
+	if v == "" {
+		return "Not Present"
+	}
+
+	return v
+}
+
+func hexBytes(b []byte) string {
+	ss := make([]string, len(b))
+	for i, c := range b {
+		ss[i] = fmt.Sprintf("%02X", c)
+	}
+
+	return strings.Join(ss, " ")
+}
+
+func le64(v uint64) []byte {
+	b := make([]byte, 8)
+	for i := range b {
+		b[i] = byte(v >> (8 * i))
+	}
+
+	return b
+}
+
+// handle formats a structure handle, 0xFFFF being the usual marker for none
+func handle(h uint16) string {
+	if h == 0xFFFF {
+		return "Not Provided"
+	}
+
+	return fmt.Sprintf("0x%04X", h)
+}
+
+// errorInfoHandle formats the Type 16 and 17 error information handle, where 0xFFFF means no error was detected
+func errorInfoHandle(h uint16) string {
+	switch h {
+	case 0xFFFE:
+		return "Not Provided"
+	case 0xFFFF:
+		return "No Error"
+	}
+
+	return fmt.Sprintf("0x%04X", h)
+}
+
+func bits(v uint16) string {
+	if v == 0xFFFF || v == 0 {
+		return "Unknown"
+	}
+
+	return fmt.Sprintf("%d bits", v)
+}
+
+func mhz(v uint16) string {
+	if v == 0 {
+		return "Unknown"
+	}
+
+	return fmt.Sprintf("%d MHz", v)
+}
+
+func mts(v uint16) string {
+	if v == 0 {
+		return "Unknown"
+	}
+
+	return fmt.Sprintf("%d MT/s", v)
+}
+
+// formatSize renders a byte count in the largest unit that divides it evenly, the way dmidecode does
+func formatSize(v uint64) string {
+	units := []string{"bytes", "kB", "MB", "GB", "TB", "PB"}
+
+	i := 0
+	for v >= 1024 && v%1024 == 0 && i < len(units)-1 {
+		v /= 1024
+		i++
+	}
+
+	return fmt.Sprintf("%d %s", v, units[i])
+}
@@ -1,3 +1,8 @@
 module github.com/rrdr20/smbtest
 
 go 1.20
+
+require (
+	golang.org/x/sys v0.15.0
+	gopkg.in/yaml.v3 v3.0.1
+)
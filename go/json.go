@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/rrdr20/smbtest/smbios"
+)
+
+// jsonTable is the JSON and YAML form of the table
+type jsonTable struct {
+	EntryPoint *smbios.EntryPoint `json:"entry_point"`
+	Structures []jsonStructure    `json:"structures"`
+}
+
+// jsonStructure holds the decoded fields of a structure, or the raw formatted area and strings when its type has no
+// decoder or it fails to decode
+type jsonStructure struct {
+	Header    smbios.Header `json:"header"`
+	Decoded   any           `json:"decoded,omitempty"`
+	Formatted []byte        `json:"formatted,omitempty"`
+	Strings   []string      `json:"strings,omitempty"`
+}
+
+func decodedTable(t *smbios.SmTable) jsonTable {
+	jt := jsonTable{EntryPoint: t.EntryPoint, Structures: []jsonStructure{}}
+	for _, s := range t.Structures {
+		js := jsonStructure{Header: s.Header}
+
+		v, err := s.Decode()
+		if _, undecoded := v.(smbios.Undecoded); err != nil || undecoded {
+			js.Formatted = s.Formatterd
+			js.Strings = s.Strings
+		} else {
+			js.Decoded = v
+		}
+		jt.Structures = append(jt.Structures, js)
+	}
+
+	return jt
+}
+
+// renderJSON writes the table as JSON with each structure decoded where possible, pretty indents it by two spaces
+func renderJSON(w io.Writer, t *smbios.SmTable, pretty bool) error {
+	enc := json.NewEncoder(w)
+	if pretty {
+		enc.SetIndent("", "  ")
+	}
+
+	return enc.Encode(decodedTable(t))
+}
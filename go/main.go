@@ -8,214 +8,317 @@ version 0.0
 package main
 
 import (
-	"bufio"
-	"bytes"
-	"encoding/binary"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rrdr20/smbtest/smbios"
 )
 
 const (
-	sysfsEntrypoint = "/sys/firmware/dmi/tables/smbios_entry_point"
-	sysfsDMI        = "/sys/firmware/dmi/tables/DMI"
-	headerLen       = 4
+	version = "0.0"
+	devMem  = "/dev/mem"
 )
 
-var (
-	anchor             = []byte("_SM_")
-	intermediateAnchor = []byte("_DMI_")
-	terminater         = []byte{0x00, 0x00}
-)
+// typeList collects the repeatable -type flag
+type typeList []uint8
 
-type EntryPoint struct {
-	Anchor                string // Anchor string (_SM_)
-	IntermediateAnchor    string // size of 5 (_DMI_)
-	Checksum              uint8
-	Length                uint8
-	Major                 uint8
-	Minor                 uint8
-	MaxStructureSize      uint16
-	EntryPointRevision    uint8   // if this value is 0 then next 5 bytes are set to 0
-	FormattedArea         [5]byte // set to 0 if EntryPointRevision is set to 0
-	IntermediateChecksum  uint8
-	StructureTableLength  uint16
-	StructureTableAddress uint32
-	NumberStructures      uint16
-	BCDRevision           uint8
-}
+func (l *typeList) String() string {
+	ss := make([]string, len(*l))
+	for i, t := range *l {
+		ss[i] = strconv.Itoa(int(t))
+	}
 
-type Header struct {
-	Type   uint8
-	Length uint8
-	Handle uint16
+	return strings.Join(ss, ",")
 }
 
-type Structure struct {
-	Formatterd []byte
-	Strings    []string
-	Header     Header
+func (l *typeList) Set(v string) error {
+	t, err := strconv.ParseUint(v, 0, 8)
+	if err != nil {
+		return fmt.Errorf("invalid structure type %q", v)
+	}
+	*l = append(*l, uint8(t))
+
+	return nil
 }
 
-type SmTable struct {
-	Structures []Structure
+// usageError is a command line mistake, reported with the usage text
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
 }
 
 func main() {
-	// If the files do not exist do not proceed, exit with error
-	_, err := os.Stat(sysfsEntrypoint)
-	if err != nil {
-		fmt.Println(err)
+	if err := run(); err != nil {
+		var ue usageError
+		if errors.As(err, &ue) {
+			fmt.Fprintln(flag.CommandLine.Output(), err)
+			flag.Usage()
+			os.Exit(2)
+		}
+
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+}
 
-	_, err = os.Stat(sysfsDMI)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+func run() (err error) {
+	var types typeList
+	format := flag.String("format", "text", "output format: text, json, yaml, dmidecode, csv or summary")
+	strict := flag.Bool("strict", false, "exit on any parse problem, such as a checksum mismatch or a truncated table")
+	flag.Var(&types, "type", "only output structures of this type, may be repeated")
+	entryPath := flag.String("entry", smbios.SysfsEntryPoint, "path to the SMBIOS entry point, such as a saved dump")
+	dmiPath := flag.String("dmi", smbios.SysfsDMI, "path to the DMI table, such as a saved dump")
+	count := flag.Bool("count", false, "print the number of structures of each type and exit")
+	validate := flag.Bool("validate", false, "check each structure for malformed lengths and string references and exit")
+	handle := flag.String("handle", "", "print only the structure with this handle, such as 0x0100, and exit")
+	noObsolete := flag.Bool("no-obsolete", false, "drop the obsolete structure types 5, 6 and 10")
+	raw := flag.Bool("raw", false, "print a hex dump of the entry point and the whole DMI table as read and exit")
+	showVersion := flag.Bool("version", false, "print the tool, supported SMBIOS and firmware SMBIOS versions and exit")
+	output := flag.String("output", "", "write the output to this file instead of stdout")
+	quiet := flag.Bool("quiet", false, "drop the text format entry point, warnings still go to stderr")
+	pretty := flag.Bool("pretty", false, "indent the json output")
+	mem := flag.Bool("mem", false, "read the tables from "+devMem+" when sysfs does not provide them (requires root)")
+	diff := flag.Bool("diff", false, "compare the two json inventories given as arguments and exit")
+	flag.Parse()
+
+	// Reject an unknown format before touching any of the files
+	switch *format {
+	case "text", "json", "yaml", "dmidecode", "csv", "summary":
+	default:
+		return usageError(fmt.Sprintf("unknown format %q", *format))
 	}
 
-	// If the file cannot be opened do not proceed, exit with error
-	smbepf, err := os.Open(sysfsEntrypoint)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	var h uint16
+	if *handle != "" {
+		v, err := strconv.ParseUint(*handle, 0, 16)
+		if err != nil {
+			return usageError(fmt.Sprintf("invalid handle %q", *handle))
+		}
+		h = uint16(v)
 	}
-	defer smbepf.Close()
 
-	// Parse and return EntryPoint info
-	ep, err := parseSmbEntryPoint(smbepf)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	if *diff && flag.NArg() != 2 {
+		return usageError("-diff needs two json inventories")
 	}
 
-	dmiTablef, err := os.Open(sysfsDMI)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	// Saved dumps and the /dev/mem fallback override the platform source, the fallback still uses the entry point when
+	// only the DMI table is missing
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	src := smbios.DefaultSource()
+	if set["entry"] || set["dmi"] {
+		src = smbios.FileSource{EntryPointPath: *entryPath, TablePath: *dmiPath}
+	}
+	if *mem && !filesExist(*entryPath, *dmiPath) {
+		src = &smbios.MemSource{Path: devMem, EntryPointPath: *entryPath}
 	}
-	defer dmiTablef.Close()
 
-	parseDmiTable(dmiTablef)
+	// The file is truncated up front so a failed run does not leave stale output behind
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}()
+		out = f
+	}
 
-	fmt.Println(*ep)
-}
+	if *diff {
+		a, err := loadInventory(flag.Arg(0))
+		if err != nil {
+			return err
+		}
+		b, err := loadInventory(flag.Arg(1))
+		if err != nil {
+			return err
+		}
+		if !diffInventories(out, a, b) {
+			return errors.New("inventories differ")
+		}
+		return nil
+	}
 
-func parseSmbEntryPoint(smbepf io.Reader) (*EntryPoint, error) {
-	// Location index of the checksum byte
-	const chksumIdx int = 4
+	if *showVersion {
+		fmt.Fprintf(out, "smbtest %s\n", version)
+		fmt.Fprintf(out, "Supports SMBIOS %s\n", smbios.SpecVersion)
+		// The firmware version is only reported when the tables can be read
+		if t, _ := smbios.ParseSource(src); t != nil && t.EntryPoint != nil {
+			fmt.Fprintf(out, "Firmware SMBIOS %s\n", t.EntryPoint.Version())
+		}
+		return nil
+	}
+
+	if *raw {
+		return dumpRaw(out, src)
+	}
 
-	b, err := io.ReadAll(smbepf)
+	t, err := parseTable(os.Stderr, src, *strict)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// If the Anchor is not present then no need to proceed, return error
-	if !bytes.HasPrefix(b, anchor) {
-		return nil, errors.New("SMBIOS anchor not found")
+	if len(types) > 0 {
+		t = filterTypes(t, types)
+	}
+	if *noObsolete {
+		t.FilterObsolete()
 	}
 
-	// Caclulate the checksum
-	if err := checksum(b[chksumIdx], chksumIdx, b); err != nil {
-		return nil, err
+	if *handle != "" {
+		s, ok := t.ByHandle(h)
+		if !ok {
+			return fmt.Errorf("no structure with handle 0x%04X", h)
+		}
+		dmidecodeStructure(out, *s)
+		return nil
 	}
 
-	ep := EntryPoint{
-		// First 4 bytes is the anchor
-		Anchor:                string(b[0:4]),
-		Checksum:              b[4],
-		Length:                b[5],
-		Major:                 b[6],
-		Minor:                 b[7],
-		MaxStructureSize:      binary.LittleEndian.Uint16(b[8:10]),
-		EntryPointRevision:    b[10],
-		IntermediateAnchor:    string(b[16:21]),
-		IntermediateChecksum:  b[21],
-		StructureTableLength:  binary.LittleEndian.Uint16(b[22:24]),
-		StructureTableAddress: binary.LittleEndian.Uint32(b[24:28]),
-		NumberStructures:      binary.LittleEndian.Uint16(b[28:30]),
-		BCDRevision:           b[30],
+	if *validate {
+		if !validateTable(out, t) {
+			return errors.New("structure problems found")
+		}
+		return nil
 	}
-	copy(ep.FormattedArea[:], b[11:16])
 
-	return &ep, nil
+	if *count {
+		printCounts(out, t)
+		return nil
+	}
+
+	return render(out, *format, t, renderOptions{quiet: *quiet, pretty: *pretty})
 }
 
-func parseDmiTable(dmiTablef io.ReadCloser) error {
-	br := bufio.NewReader(dmiTablef)
+// parseTable reads and parses src. A checksum mismatch, a truncated or malformed table or a table that disagrees with
+// the entry point still returns what was parsed, that is only a warning written to warn unless running in strict mode.
+// The warning is kept even when quiet, stdout only ever carries the data.
+func parseTable(warn io.Writer, src smbios.Source, strict bool) (*smbios.SmTable, error) {
+	t, err := smbios.ParseSource(src)
+	if err != nil && t != nil && !strict {
+		fmt.Fprintln(warn, "warning:", err)
+		return t, nil
+	}
 
-	for {
-		buf := make([]byte, headerLen)
-		if _, err := io.ReadFull(br, buf); err != nil {
-			break
-		}
+	return t, err
+}
+
+// renderOptions tweak the output formats
+type renderOptions struct {
+	quiet  bool // drop the entry point line that follows the text format
+	pretty bool // indent the json format
+}
 
-		h := Header{
-			Type:   buf[0],
-			Length: buf[1],
-			Handle: binary.LittleEndian.Uint16(buf[2:4]),
+func render(w io.Writer, format string, t *smbios.SmTable, opts renderOptions) error {
+	switch format {
+	case "json":
+		return renderJSON(w, t, opts.pretty)
+	case "yaml":
+		return renderYAML(w, t)
+	case "dmidecode":
+		return renderDmidecode(w, t)
+	case "csv":
+		return renderMemoryCSV(w, t)
+	case "summary":
+		for _, s := range t.Structures {
+			line := fmt.Sprintf("%3d %s [0x%04X] %s", s.Header.Type, s.Header.TypeName(), s.Header.Handle, s.Name())
+			fmt.Fprintln(w, strings.TrimRight(line, " "))
 		}
+	default:
+		for _, s := range t.Structures {
+			fmt.Fprintf(w, "%s: %+v\n", s.Header.TypeName(), s)
+		}
+		if !opts.quiet && t.EntryPoint != nil {
+			fmt.Fprintln(w, *t.EntryPoint)
+		}
+	}
 
-		length := h.Length - headerLen
+	return nil
+}
 
-		buf = make([]byte, length)
-		if _, err := io.ReadFull(br, buf); err != nil {
-			return errors.New("unable to read dmi data")
-		}
+// dumpRaw writes the entry point and DMI table bytes from src as hex, without parsing them
+func dumpRaw(w io.Writer, src smbios.Source) error {
+	ep, err := src.EntryPoint()
+	if err != nil {
+		return err
+	}
+	tbl, err := src.Table()
+	if err != nil {
+		return err
+	}
 
-		s := Structure{
-			Header:     h,
-			Formatterd: buf,
-			Strings:    []string{},
-		}
+	fmt.Fprintf(w, "Entry point, %d bytes:\n%s\n", len(ep), smbios.HexDump(ep))
+	fmt.Fprintf(w, "DMI table, %d bytes:\n%s", len(tbl), smbios.HexDump(tbl))
 
-		for {
-			term, err := br.Peek(2)
-			if err != nil {
-				return errors.New("unable to read dmi data")
-			}
+	return nil
+}
+
+// printCounts writes one line per structure type present, in type order
+func printCounts(w io.Writer, t *smbios.SmTable) {
+	counts := t.TypeCounts()
+	typs := make([]int, 0, len(counts))
+	for typ := range counts {
+		typs = append(typs, int(typ))
+	}
+	sort.Ints(typs)
+
+	for _, typ := range typs {
+		h := smbios.Header{Type: uint8(typ)}
+		fmt.Fprintf(w, "%3d  %-40s %d\n", typ, h.TypeName(), counts[uint8(typ)])
+	}
+}
 
-			if bytes.Equal(term, terminater) {
-				br.Discard(2)
-				break
-			} else {
-				raw, err := br.ReadBytes(0x00)
-				if err != nil {
-					return errors.New("read err parsing string")
-				}
-				ss := bytes.TrimRight(raw, "\x00")
-				s.Strings = append(s.Strings, string(ss))
-				peek, err := br.Peek(1)
-				if err != nil {
-					return errors.New("unable to read dmi data")
-				}
-				if bytes.Equal(peek, []byte{0x00}) {
-					br.Discard(1)
-					break
-				}
+// validateTable writes one line per structure problem and reports whether the table is free of them
+func validateTable(w io.Writer, t *smbios.SmTable) bool {
+	ok := true
+	for _, s := range t.Structures {
+		if err := s.Validate(); err != nil {
+			ok = false
+			for _, line := range strings.Split(err.Error(), "\n") {
+				fmt.Fprintf(w, "Handle 0x%04X, DMI type %d: %s\n", s.Header.Handle, s.Header.Type, line)
 			}
 		}
-
-		fmt.Printf("%+v\n", s)
 	}
 
-	return nil
+	return ok
 }
 
-func checksum(checksum uint8, idx int, b []byte) error {
-	chk := checksum
-	for i := range b {
-		if i == idx {
-			continue
+// filterTypes returns a table holding only the structures of the requested types, in table order and each one once
+// however often its type was requested
+func filterTypes(t *smbios.SmTable, types []uint8) *smbios.SmTable {
+	want := map[uint8]bool{}
+	for _, typ := range types {
+		want[typ] = true
+	}
+
+	ft := smbios.SmTable{EntryPoint: t.EntryPoint, Structures: []smbios.Structure{}}
+	for _, s := range t.Structures {
+		if want[s.Header.Type] {
+			ft.Structures = append(ft.Structures, s)
 		}
-		chk += b[i]
 	}
 
-	if chk != 0 {
-		return errors.New("Invalid checksum")
+	return &ft
+}
+
+// filesExist reports whether all of the given files are present
+func filesExist(paths ...string) bool {
+	for _, p := range paths {
+		if _, err := os.Stat(p); err != nil {
+			return false
+		}
 	}
 
-	return nil
+	return true
 }
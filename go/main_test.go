@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rrdr20/smbtest/smbios"
+)
+
+// oemTable builds a table holding a Type 11 structure with the given OEM string and the end of table structure
+func oemTable(t *testing.T, oem string) *smbios.SmTable {
+	t.Helper()
+
+	raw := []byte{11, 5, 0x00, 0x0B, 1}
+	raw = append(raw, oem...)
+	raw = append(raw, 0, 0)
+	raw = append(raw, 127, 4, 0xFF, 0xFF, 0, 0)
+
+	tbl, err := smbios.ParseTableBytes(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tbl
+}
+
+// writeInventory saves the table in the json format and returns the path
+func writeInventory(t *testing.T, tbl *smbios.SmTable, name string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := renderJSON(&buf, tbl, false); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestDiffOEMStrings(t *testing.T) {
+	a, err := loadInventory(writeInventory(t, oemTable(t, "before"), "a.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := loadInventory(writeInventory(t, oemTable(t, "after"), "b.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if !diffInventories(&out, a, a) || out.Len() != 0 {
+		t.Errorf("identical inventories differ:\n%s", out.String())
+	}
+
+	out.Reset()
+	if diffInventories(&out, a, b) {
+		t.Fatal("changed OEM string not reported")
+	}
+	want := `Handle 0x0B00, DMI type 11: decoded[0] "before" -> "after"`
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("diff output missing %q:\n%s", want, out.String())
+	}
+}
+
+func TestDiffMemorySerials(t *testing.T) {
+	// serialTable gives the two populated DIMMs of the fixture the given serial numbers
+	serialTable := func(serials map[uint16]string) *smbios.SmTable {
+		tbl := readFixtureTable(t, "synthetic_sm3")
+		for i, s := range tbl.Structures {
+			serial, ok := serials[s.Header.Handle]
+			if s.Header.Type != 17 || !ok {
+				continue
+			}
+			s.Strings = append([]string{}, s.Strings...)
+			s.Strings[s.Formatterd[0x18-4]-1] = serial
+			tbl.Structures[i] = s
+		}
+		return tbl
+	}
+
+	a, err := loadInventory(writeInventory(t, serialTable(map[uint16]string{0x0011: "A1", 0x0012: "B1"}), "a.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := loadInventory(writeInventory(t, serialTable(map[uint16]string{0x0011: "B1", 0x0012: "A1"}), "b.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if diffInventories(&out, a, b) {
+		t.Fatal("swapped serial numbers not reported")
+	}
+	want := `Handle 0x0011, DMI type 17: changed Memory Device
+Handle 0x0011, DMI type 17: serial_number "A1" -> "B1"
+Handle 0x0012, DMI type 17: changed Memory Device
+Handle 0x0012, DMI type 17: serial_number "B1" -> "A1"
+`
+	if out.String() != want {
+		t.Errorf("diff output\ngot\n%swant\n%s", out.String(), want)
+	}
+}
+
+func TestDiffDuplicateHandle(t *testing.T) {
+	tbl := oemTable(t, "oem")
+	tbl.Structures = append(tbl.Structures, tbl.Structures[0])
+
+	_, err := loadInventory(writeInventory(t, tbl, "dup.json"))
+	if err == nil || !strings.Contains(err.Error(), "more than one type 11 structure with handle 0x0B00") {
+		t.Fatalf("got %v, want a duplicate handle error", err)
+	}
+}
+
+func TestFilterTypes(t *testing.T) {
+	tbl := &smbios.SmTable{Structures: []smbios.Structure{
+		{Header: smbios.Header{Type: 0, Handle: 0x0000}},
+		{Header: smbios.Header{Type: 17, Handle: 0x1100}},
+		{Header: smbios.Header{Type: 1, Handle: 0x0100}},
+		{Header: smbios.Header{Type: 17, Handle: 0x1101}},
+	}}
+
+	tests := []struct {
+		name  string
+		types []uint8
+		want  []uint16
+	}{
+		{"table order", []uint8{17, 0}, []uint16{0x0000, 0x1100, 0x1101}},
+		{"repeated type", []uint8{17, 17}, []uint16{0x1100, 0x1101}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []uint16{}
+			for _, s := range filterTypes(tbl, tt.types).Structures {
+				got = append(got, s.Header.Handle)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("handles\ngot  %#04x\nwant %#04x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDmidecodeHandles(t *testing.T) {
+	// Type 35 without threshold data, the 0xFFFF threshold handle is not an error indication
+	raw := []byte{35, 0x0B, 0x00, 0x23, 1, 0x00, 0x22, 0x00, 0x1A, 0xFF, 0xFF}
+	raw = append(raw, "LM78A"...)
+	raw = append(raw, 0, 0)
+	tbl, err := smbios.ParseTableBytes(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	dmidecodeStructure(&out, tbl.Structures[0])
+	for _, want := range []string{"\tManagement Device Handle: 0x2200\n", "\tThreshold Handle: Not Provided\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "No Error") {
+		t.Errorf("non-error handle rendered as No Error:\n%s", out.String())
+	}
+}
+
+func TestParseTableStrict(t *testing.T) {
+	testdata := filepath.Join("smbios", "testdata")
+	entry, err := os.ReadFile(filepath.Join(testdata, "synthetic_sm2_entry.bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry[4]++
+	badEntry := filepath.Join(t.TempDir(), "entry.bin")
+	if err := os.WriteFile(badEntry, entry, 0644); err != nil {
+		t.Fatal(err)
+	}
+	src := smbios.FileSource{EntryPointPath: badEntry, TablePath: filepath.Join(testdata, "synthetic_sm2_dmi.bin")}
+
+	var warn bytes.Buffer
+	tbl, err := parseTable(&warn, src, false)
+	if err != nil || tbl == nil || len(tbl.Structures) == 0 {
+		t.Fatalf("default mode: got %v, want the parsed table", err)
+	}
+	if !strings.HasPrefix(warn.String(), "warning: ") {
+		t.Errorf("default mode: warning %q", warn.String())
+	}
+
+	warn.Reset()
+	var chkErr *smbios.ChecksumError
+	if _, err := parseTable(&warn, src, true); !errors.As(err, &chkErr) {
+		t.Errorf("strict mode: got %v, want a *ChecksumError", err)
+	}
+	if warn.Len() != 0 {
+		t.Errorf("strict mode: unexpected warning %q", warn.String())
+	}
+}
+
+// readFixtureTable parses one of the smbios package fixtures
+func readFixtureTable(t *testing.T, name string) *smbios.SmTable {
+	t.Helper()
+
+	testdata := filepath.Join("smbios", "testdata")
+	tbl, err := smbios.ParseSource(smbios.FileSource{
+		EntryPointPath: filepath.Join(testdata, name+"_entry.bin"),
+		TablePath:      filepath.Join(testdata, name+"_dmi.bin"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tbl
+}
+
+func TestRenderMemoryCSV(t *testing.T) {
+	tbl := readFixtureTable(t, "synthetic_sm2")
+
+	// Mark A2 as an unknown size, the size word at offset 0x0C set to 0xFFFF
+	for i, s := range tbl.Structures {
+		if s.Header.Type == 17 && s.Strings[0] == "A2" {
+			f := append([]byte{}, s.Formatterd...)
+			f[0x0C-4], f[0x0D-4] = 0xFF, 0xFF
+			tbl.Structures[i].Formatterd = f
+		}
+	}
+
+	var out bytes.Buffer
+	if err := renderMemoryCSV(&out, tbl); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `locator,bank_locator,size_mb,speed,type,manufacturer,part_number,serial_number
+A1,Not Specified,16384,2133,DDR4,00AD00B300AD,HMA42GR7AFR4N-TF,SCRUBBED
+A2,Not Specified,unknown,2133,DDR4,00AD00B300AD,HMA42GR7AFR4N-TF,SCRUBBED
+B1,Not Specified,0,0,Unknown,Not Specified,Not Specified,Not Specified
+B2,Not Specified,0,0,Unknown,Not Specified,Not Specified,Not Specified
+`
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+}
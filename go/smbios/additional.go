@@ -0,0 +1,60 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// AdditionalInformation is the decoded Type 40 structure
+type AdditionalInformation struct {
+	Entries []AdditionalInformationEntry `json:"entries"`
+}
+
+// AdditionalInformationEntry is one variable length entry of a Type 40 structure, patching data onto the field at
+// ReferencedOffset of the structure with ReferencedHandle
+type AdditionalInformationEntry struct {
+	ReferencedHandle uint16 `json:"referenced_handle"`
+	ReferencedOffset uint8  `json:"referenced_offset"`
+	String           string `json:"string"`
+	Value            []byte `json:"value"`
+}
+
+// additionalEntryLen is the fixed part of an entry: length, handle, offset and string
+const additionalEntryLen = 5
+
+// AdditionalInformation decodes a Type 40 (Additional Information) structure. Each entry gives its own length, an
+// entry that is too short or runs past the end of the structure is an error.
+func (s Structure) AdditionalInformation() (*AdditionalInformation, error) {
+	if err := s.check(40, 0x05); err != nil {
+		return nil, err
+	}
+
+	ai := AdditionalInformation{Entries: []AdditionalInformationEntry{}}
+	count, _ := s.byteAt(0x04)
+	offset := 0x05
+	for i := 0; i < int(count); i++ {
+		n, ok := s.byteAt(offset)
+		if !ok {
+			return nil, fmt.Errorf("type 40 structure too short for %d entries, found %d", count, i)
+		}
+		if n < additionalEntryLen {
+			return nil, fmt.Errorf("type 40 entry %d length %d is shorter than %d bytes", i, n, additionalEntryLen)
+		}
+
+		b, ok := s.field(offset, int(n))
+		if !ok {
+			return nil, fmt.Errorf("type 40 entry %d of %d bytes runs past the end of the structure", i, n)
+		}
+
+		e := AdditionalInformationEntry{
+			ReferencedHandle: binary.LittleEndian.Uint16(b[1:3]),
+			ReferencedOffset: b[3],
+			String:           s.String(b[4]),
+			Value:            append([]byte{}, b[additionalEntryLen:]...),
+		}
+		ai.Entries = append(ai.Entries, e)
+		offset += int(n)
+	}
+
+	return &ai, nil
+}
@@ -0,0 +1,84 @@
+package smbios
+
+import (
+	"errors"
+)
+
+// BaseboardInformation is the decoded Type 2 structure
+type BaseboardInformation struct {
+	Manufacturer      string    `json:"manufacturer"`
+	Product           string    `json:"product"`
+	Version           string    `json:"version"`
+	SerialNumber      string    `json:"serial_number"`
+	AssetTag          string    `json:"asset_tag"`
+	FeatureFlags      uint8     `json:"feature_flags"`
+	LocationInChassis string    `json:"location_in_chassis"`
+	ChassisHandle     uint16    `json:"chassis_handle"`
+	BoardType         BoardType `json:"board_type"`
+	ObjectHandles     []uint16  `json:"object_handles"`
+}
+
+// BoardType is the kind of board, a Motherboard on most systems.
+type BoardType uint8
+
+var boardTypeNames = map[BoardType]string{
+	0x01: "Unknown",
+	0x02: "Other",
+	0x03: "Server Blade",
+	0x04: "Connectivity Switch",
+	0x05: "System Management Module",
+	0x06: "Processor Module",
+	0x07: "I/O Module",
+	0x08: "Memory Module",
+	0x09: "Daughter Board",
+	0x0A: "Motherboard",
+	0x0B: "Processor+Memory Module",
+	0x0C: "Processor+I/O Module",
+	0x0D: "Interconnect Board",
+}
+
+func (t BoardType) String() string {
+	return enumName(boardTypeNames, t)
+}
+
+func (t BoardType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// Baseboard decodes a Type 2 (Baseboard Information) structure
+func (s Structure) Baseboard() (*BaseboardInformation, error) {
+	if err := s.check(2, 0x08); err != nil {
+		return nil, err
+	}
+
+	bb := BaseboardInformation{
+		Manufacturer:      s.stringAt(0x04),
+		Product:           s.stringAt(0x05),
+		Version:           s.stringAt(0x06),
+		SerialNumber:      s.stringAt(0x07),
+		AssetTag:          s.stringAt(0x08),
+		LocationInChassis: s.stringAt(0x0A),
+		ObjectHandles:     []uint16{},
+	}
+	bb.FeatureFlags, _ = s.byteAt(0x09)
+	bb.ChassisHandle, _ = s.word(0x0B)
+	boardType, _ := s.byteAt(0x0D)
+	bb.BoardType = BoardType(boardType)
+
+	// Older boards stop before the contained handles
+	count, ok := s.byteAt(0x0E)
+	if !ok {
+		return &bb, nil
+	}
+
+	// The contained handles are a count driven array of words
+	for i := 0; i < int(count); i++ {
+		h, ok := s.word(0x0F + i*2)
+		if !ok {
+			return nil, errors.New("type 2 contained object handles exceed structure length")
+		}
+		bb.ObjectHandles = append(bb.ObjectHandles, h)
+	}
+
+	return &bb, nil
+}
@@ -0,0 +1,65 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// PortableBattery is the decoded Type 22 structure. Serial number, manufacture date and chemistry come from the
+// SBDS fields (2.2+) when the 2.1 fields are not set.
+type PortableBattery struct {
+	Location        string `json:"location"`
+	Manufacturer    string `json:"manufacturer"`
+	ManufactureDate string `json:"manufacture_date"` // SBDS dates are rendered as YYYY-MM-DD
+	SerialNumber    string `json:"serial_number"`    // SBDS serials are rendered as 4 hex digits
+	DeviceName      string `json:"device_name"`
+	Chemistry       uint8  `json:"chemistry"`       // 2 means unknown, see SBDSChemistry
+	SBDSChemistry   string `json:"sbds_chemistry"`  // 2.2+
+	DesignCapacity  uint32 `json:"design_capacity"` // mWh, multiplier applied, 0 is unknown
+	DesignVoltage   uint16 `json:"design_voltage"`  // mV, 0 is unknown
+	SBDSVersion     string `json:"sbds_version"`
+	MaximumError    uint8  `json:"maximum_error"` // percent, 0xFF is unknown
+	OEMSpecific     uint32 `json:"oem_specific"`  // 2.2+
+}
+
+// Battery decodes a Type 22 (Portable Battery) structure
+func (s Structure) Battery() (*PortableBattery, error) {
+	if err := s.check(22, 0x10); err != nil {
+		return nil, err
+	}
+
+	pb := PortableBattery{
+		Location:        s.stringAt(0x04),
+		Manufacturer:    s.stringAt(0x05),
+		ManufactureDate: s.stringAt(0x06),
+		SerialNumber:    s.stringAt(0x07),
+		DeviceName:      s.stringAt(0x08),
+		SBDSVersion:     s.stringAt(0x0E),
+		SBDSChemistry:   s.stringAt(0x14),
+	}
+	pb.Chemistry, _ = s.byteAt(0x09)
+	capacity, _ := s.word(0x0A)
+	pb.DesignVoltage, _ = s.word(0x0C)
+	pb.MaximumError, _ = s.byteAt(0x0F)
+	pb.OEMSpecific, _ = s.dword(0x16)
+
+	// 2.1 structures have no multiplier
+	pb.DesignCapacity = uint32(capacity)
+	if mult, ok := s.byteAt(0x15); ok && mult > 1 {
+		pb.DesignCapacity *= uint32(mult)
+	}
+
+	// The 2.2 SBDS fields are used when the matching string reference is 0
+	if ref, _ := s.byteAt(0x07); ref == 0 {
+		if serial, ok := s.word(0x10); ok {
+			pb.SerialNumber = fmt.Sprintf("%04X", serial)
+		}
+	}
+	if ref, _ := s.byteAt(0x06); ref == 0 {
+		if date, ok := s.word(0x12); ok {
+			// Bits 15:9 are years since 1980, 8:5 the month and 4:0 the day
+			pb.ManufactureDate = fmt.Sprintf("%04d-%02d-%02d", 1980+int(date>>9), (date>>5)&0x0F, date&0x1F)
+		}
+	}
+
+	return &pb, nil
+}
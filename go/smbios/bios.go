@@ -0,0 +1,147 @@
+package smbios
+
+// BIOSInformation is the decoded Type 0 structure
+type BIOSInformation struct {
+	Vendor              string `json:"vendor"`
+	Version             string `json:"version"`
+	StartingSegment     uint16 `json:"starting_segment"`
+	ReleaseDate         string `json:"release_date"`
+	ROMSize             uint64 `json:"rom_size"` // size in bytes
+	Characteristics     uint64 `json:"characteristics"`
+	CharacteristicsExt1 uint8  `json:"characteristics_ext1"` // 2.4+
+	CharacteristicsExt2 uint8  `json:"characteristics_ext2"` // 2.4+
+	BIOSMajor           uint8  `json:"bios_major"`           // 2.4+
+	BIOSMinor           uint8  `json:"bios_minor"`           // 2.4+
+	FirmwareMajor       uint8  `json:"firmware_major"`       // 2.4+, embedded controller firmware
+	FirmwareMinor       uint8  `json:"firmware_minor"`       // 2.4+, embedded controller firmware
+}
+
+// BIOS decodes a Type 0 (BIOS Information) structure
+func (s Structure) BIOS() (*BIOSInformation, error) {
+	if err := s.check(0, 0x12); err != nil {
+		return nil, err
+	}
+
+	bi := BIOSInformation{
+		Vendor:      s.stringAt(0x04),
+		Version:     s.stringAt(0x05),
+		ReleaseDate: s.stringAt(0x08),
+	}
+	bi.StartingSegment, _ = s.word(0x06)
+	romSize, _ := s.byteAt(0x09)
+	bi.ROMSize = (uint64(romSize) + 1) * 64 * 1024
+	bi.Characteristics, _ = s.qword(0x0A)
+
+	// 2.4+ fields read as 0 on shorter structures
+	bi.CharacteristicsExt1, _ = s.byteAt(0x12)
+	bi.CharacteristicsExt2, _ = s.byteAt(0x13)
+	bi.BIOSMajor, _ = s.byteAt(0x14)
+	bi.BIOSMinor, _ = s.byteAt(0x15)
+	bi.FirmwareMajor, _ = s.byteAt(0x16)
+	bi.FirmwareMinor, _ = s.byteAt(0x17)
+
+	// 3.1+ moves large ROM sizes to the extended field, bits 15:14 are the unit
+	if ext, ok := s.word(0x18); ok && romSize == 0xFF {
+		size := uint64(ext & 0x3FFF)
+		switch ext >> 14 {
+		case 0:
+			bi.ROMSize = size << 20
+		case 1:
+			bi.ROMSize = size << 30
+		}
+	}
+
+	return &bi, nil
+}
+
+// Characteristics bit names, indexed by bit number. Bits 0 through 2 are reserved or unknown and bits 32 through 63
+// are vendor specific.
+var biosCharacteristics = [32]string{
+	3:  "BIOS characteristics not supported",
+	4:  "ISA is supported",
+	5:  "MCA is supported",
+	6:  "EISA is supported",
+	7:  "PCI is supported",
+	8:  "PC Card (PCMCIA) is supported",
+	9:  "PNP is supported",
+	10: "APM is supported",
+	11: "BIOS is upgradeable",
+	12: "BIOS shadowing is allowed",
+	13: "VLB is supported",
+	14: "ESCD support is available",
+	15: "Boot from CD is supported",
+	16: "Selectable boot is supported",
+	17: "BIOS ROM is socketed",
+	18: "Boot from PC Card (PCMCIA) is supported",
+	19: "EDD is supported",
+	20: "Japanese floppy for NEC 9800 1.2 MB is supported (int 13h)",
+	21: "Japanese floppy for Toshiba 1.2 MB is supported (int 13h)",
+	22: "5.25\"/360 kB floppy services are supported (int 13h)",
+	23: "5.25\"/1.2 MB floppy services are supported (int 13h)",
+	24: "3.5\"/720 kB floppy services are supported (int 13h)",
+	25: "3.5\"/2.88 MB floppy services are supported (int 13h)",
+	26: "Print screen service is supported (int 5h)",
+	27: "8042 keyboard services are supported (int 9h)",
+	28: "Serial services are supported (int 14h)",
+	29: "Printer services are supported (int 17h)",
+	30: "CGA/mono video services are supported (int 10h)",
+	31: "NEC PC-98",
+}
+
+// Extension byte 1 (offset 12h) bit names
+var biosCharacteristicsExt1 = [8]string{
+	"ACPI is supported",
+	"USB legacy is supported",
+	"AGP is supported",
+	"I2O boot is supported",
+	"LS-120 boot is supported",
+	"ATAPI Zip drive boot is supported",
+	"IEEE 1394 boot is supported",
+	"Smart battery is supported",
+}
+
+// Extension byte 2 (offset 13h) bit names, bits 5 through 7 are reserved
+var biosCharacteristicsExt2 = [8]string{
+	"BIOS boot specification is supported",
+	"Function key-initiated network boot is supported",
+	"Targeted content distribution is supported",
+	"UEFI is supported",
+	"System is a virtual machine",
+}
+
+// CharacteristicsSet returns the names of the characteristics set in the bitfield and both extension bytes, in
+// spec order
+func (bi *BIOSInformation) CharacteristicsSet() []string {
+	set := []string{}
+	add := func(names []string, v uint64) {
+		for i, name := range names {
+			if name != "" && v&(1<<i) != 0 {
+				set = append(set, name)
+			}
+		}
+	}
+
+	add(biosCharacteristics[:], bi.Characteristics)
+	add(biosCharacteristicsExt1[:], uint64(bi.CharacteristicsExt1))
+	add(biosCharacteristicsExt2[:], uint64(bi.CharacteristicsExt2))
+
+	return set
+}
+
+// CharacteristicsFlags returns every named characteristic with whether it is set
+func (bi *BIOSInformation) CharacteristicsFlags() map[string]bool {
+	flags := map[string]bool{}
+	for _, names := range [][]string{biosCharacteristics[:], biosCharacteristicsExt1[:], biosCharacteristicsExt2[:]} {
+		for _, name := range names {
+			if name != "" {
+				flags[name] = false
+			}
+		}
+	}
+
+	for _, name := range bi.CharacteristicsSet() {
+		flags[name] = true
+	}
+
+	return flags
+}
@@ -0,0 +1,56 @@
+package smbios
+
+// Boot status values from DSP0134 3.2.0 section 7.33.2
+var bootStatusNames = map[uint8]string{
+	0: "No errors detected",
+	1: "No bootable media",
+	2: "Operating system failed to load",
+	3: "Firmware-detected hardware failure",
+	4: "Operating system-detected hardware failure",
+	5: "User-requested boot",
+	6: "System security violation",
+	7: "Previously-requested image",
+	8: "System watchdog timer expired",
+}
+
+// SystemBootInformation is the decoded Type 32 structure
+type SystemBootInformation struct {
+	Status         uint8  `json:"status"` // 0 when the boot completed without errors
+	StatusName     string `json:"status_name"`
+	AdditionalData []byte `json:"additional_data"` // status specific data following the status byte
+}
+
+// SystemBoot decodes a Type 32 (System Boot Information) structure
+func (s Structure) SystemBoot() (*SystemBootInformation, error) {
+	if err := s.check(32, 0x0B); err != nil {
+		return nil, err
+	}
+
+	// Offsets 0x04 to 0x09 are reserved, the boot status runs from 0x0A to the end of the structure
+	var sb SystemBootInformation
+	sb.Status, _ = s.byteAt(0x0A)
+	sb.StatusName = bootStatusName(sb.Status)
+	sb.AdditionalData = []byte{}
+	if n := int(s.Header.Length) - 0x0B; n > 0 {
+		if b, ok := s.field(0x0B, n); ok {
+			sb.AdditionalData = append(sb.AdditionalData, b...)
+		}
+	}
+
+	return &sb, nil
+}
+
+func bootStatusName(status uint8) string {
+	if name, ok := bootStatusNames[status]; ok {
+		return name
+	}
+
+	switch {
+	case status >= 192:
+		return "Product-specific"
+	case status >= 128:
+		return "Vendor/OEM-specific"
+	}
+
+	return "Reserved"
+}
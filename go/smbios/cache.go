@@ -0,0 +1,162 @@
+package smbios
+
+// CacheInformation is the decoded Type 7 structure
+type CacheInformation struct {
+	SocketDesignation   string             `json:"socket_designation"`
+	Configuration       uint16             `json:"configuration"`
+	Level               uint8              `json:"level"` // 1 through 8
+	Socketed            bool               `json:"socketed"`
+	Location            CacheLocation      `json:"location"`
+	Enabled             bool               `json:"enabled"`
+	OperationalMode     CacheMode          `json:"operational_mode"`
+	MaximumSize         uint64             `json:"maximum_size"`   // bytes
+	InstalledSize       uint64             `json:"installed_size"` // bytes
+	SupportedSRAMType   uint16             `json:"supported_sram_type"`
+	CurrentSRAMType     uint16             `json:"current_sram_type"`
+	Speed               uint8              `json:"speed"`                 // 2.1+, ns
+	ErrorCorrectionType ErrorCorrection    `json:"error_correction_type"` // 2.1+
+	SystemCacheType     CacheType          `json:"system_cache_type"`     // 2.1+
+	Associativity       CacheAssociativity `json:"associativity"`         // 2.1+
+}
+
+// CacheMode is the write policy from bits 8 and 9 of the cache configuration.
+type CacheMode uint8
+
+var cacheModeNames = map[CacheMode]string{
+	0: "Write Through",
+	1: "Write Back",
+	2: "Varies With Memory Address",
+	3: "Unknown",
+}
+
+func (m CacheMode) String() string {
+	return enumName(cacheModeNames, m)
+}
+
+func (m CacheMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// CacheLocation is whether the cache is on the processor die, from bits 5 and 6 of the configuration.
+type CacheLocation uint8
+
+var cacheLocationNames = map[CacheLocation]string{
+	0: "Internal",
+	1: "External",
+	2: "Reserved",
+	3: "Unknown",
+}
+
+func (l CacheLocation) String() string {
+	return enumName(cacheLocationNames, l)
+}
+
+func (l CacheLocation) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// CacheType is the kind of data the cache holds.
+type CacheType uint8
+
+var cacheTypeNames = map[CacheType]string{
+	1: "Other",
+	2: "Unknown",
+	3: "Instruction",
+	4: "Data",
+	5: "Unified",
+}
+
+func (t CacheType) String() string {
+	return enumName(cacheTypeNames, t)
+}
+
+func (t CacheType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// CacheAssociativity is the set associativity of the cache.
+type CacheAssociativity uint8
+
+var cacheAssociativityNames = map[CacheAssociativity]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Direct Mapped",
+	0x04: "2-way Set-associative",
+	0x05: "4-way Set-associative",
+	0x06: "Fully Associative",
+	0x07: "8-way Set-associative",
+	0x08: "16-way Set-associative",
+	0x09: "12-way Set-associative",
+	0x0A: "24-way Set-associative",
+	0x0B: "32-way Set-associative",
+	0x0C: "48-way Set-associative",
+	0x0D: "64-way Set-associative",
+	0x0E: "20-way Set-associative",
+}
+
+func (a CacheAssociativity) String() string {
+	return enumName(cacheAssociativityNames, a)
+}
+
+func (a CacheAssociativity) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// Cache decodes a Type 7 (Cache Information) structure
+func (s Structure) Cache() (*CacheInformation, error) {
+	if err := s.check(7, 0x0F); err != nil {
+		return nil, err
+	}
+
+	config, _ := s.word(0x05)
+	maxSize, _ := s.word(0x07)
+	installedSize, _ := s.word(0x09)
+	ci := CacheInformation{
+		SocketDesignation: s.stringAt(0x04),
+		Configuration:     config,
+		Level:             uint8(config&0x07) + 1,
+		Socketed:          config&0x08 != 0,
+		Location:          CacheLocation(config>>5) & 0x03,
+		Enabled:           config&0x80 != 0,
+		OperationalMode:   CacheMode(config>>8) & 0x03,
+		MaximumSize:       cacheSize16(maxSize),
+		InstalledSize:     cacheSize16(installedSize),
+	}
+	ci.SupportedSRAMType, _ = s.word(0x0B)
+	ci.CurrentSRAMType, _ = s.word(0x0D)
+	ci.Speed, _ = s.byteAt(0x0F)
+	ecc, _ := s.byteAt(0x10)
+	sysType, _ := s.byteAt(0x11)
+	assoc, _ := s.byteAt(0x12)
+	ci.ErrorCorrectionType = ErrorCorrection(ecc)
+	ci.SystemCacheType = CacheType(sysType)
+	ci.Associativity = CacheAssociativity(assoc)
+
+	// 3.1+ sets the word sizes to 0xFFFF when the value only fits in the dword fields
+	if v, ok := s.dword(0x13); ok && maxSize == 0xFFFF {
+		ci.MaximumSize = cacheSize32(v)
+	}
+	if v, ok := s.dword(0x17); ok && installedSize == 0xFFFF {
+		ci.InstalledSize = cacheSize32(v)
+	}
+
+	return &ci, nil
+}
+
+// cacheSize16 converts a word cache size to bytes, bit 15 selects 64K granularity over 1K
+func cacheSize16(v uint16) uint64 {
+	if v&0x8000 != 0 {
+		return uint64(v&0x7FFF) << 16
+	}
+
+	return uint64(v) << 10
+}
+
+// cacheSize32 converts a dword cache size to bytes, bit 31 selects 64K granularity over 1K
+func cacheSize32(v uint32) uint64 {
+	if v&0x80000000 != 0 {
+		return uint64(v&0x7FFFFFFF) << 16
+	}
+
+	return uint64(v) << 10
+}
@@ -0,0 +1,45 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MemoryChannel is the decoded Type 37 structure
+type MemoryChannel struct {
+	ChannelType uint8                 `json:"channel_type"` // 3 RamBus, 4 SyncLink
+	MaximumLoad uint8                 `json:"maximum_load"`
+	DeviceCount uint8                 `json:"device_count"`
+	Devices     []MemoryChannelDevice `json:"devices"`
+}
+
+// MemoryChannelDevice is one 3 byte device entry of a Type 37 structure
+type MemoryChannelDevice struct {
+	Load   uint8  `json:"load"`
+	Handle uint16 `json:"handle"` // Type 17 memory device
+}
+
+// MemoryChannel decodes a Type 37 (Memory Channel) structure
+func (s Structure) MemoryChannel() (*MemoryChannel, error) {
+	if err := s.check(37, 0x07); err != nil {
+		return nil, err
+	}
+
+	mc := MemoryChannel{Devices: []MemoryChannelDevice{}}
+	mc.ChannelType, _ = s.byteAt(0x04)
+	mc.MaximumLoad, _ = s.byteAt(0x05)
+	mc.DeviceCount, _ = s.byteAt(0x06)
+
+	list, ok := s.field(0x07, int(mc.DeviceCount)*3)
+	if !ok {
+		return nil, fmt.Errorf("type 37 structure too short for %d devices", mc.DeviceCount)
+	}
+	for i := 0; i < len(list); i += 3 {
+		mc.Devices = append(mc.Devices, MemoryChannelDevice{
+			Load:   list[i],
+			Handle: binary.LittleEndian.Uint16(list[i+1 : i+3]),
+		})
+	}
+
+	return &mc, nil
+}
@@ -0,0 +1,174 @@
+package smbios
+
+import (
+	"errors"
+)
+
+// ChassisInformation is the decoded Type 3 structure
+type ChassisInformation struct {
+	Manufacturer      string             `json:"manufacturer"`
+	Type              ChassisType        `json:"type"` // lock bit masked off
+	Lock              bool               `json:"lock"`
+	Version           string             `json:"version"`
+	SerialNumber      string             `json:"serial_number"`
+	AssetTag          string             `json:"asset_tag"`
+	BootUpState       ChassisState       `json:"boot_up_state"`      // 2.1+
+	PowerSupplyState  ChassisState       `json:"power_supply_state"` // 2.1+
+	ThermalState      ChassisState       `json:"thermal_state"`      // 2.1+
+	SecurityStatus    ChassisSecurity    `json:"security_status"`    // 2.1+
+	OEMDefined        uint32             `json:"oem_defined"`        // 2.3+
+	Height            uint8              `json:"height"`             // 2.3+, in U, 0 is unspecified
+	NumberPowerCords  uint8              `json:"number_power_cords"` // 2.3+
+	ContainedElements []ContainedElement `json:"contained_elements"` // 2.3+
+	SKUNumber         string             `json:"sku_number"`         // 2.7+
+}
+
+// ContainedElement is one record of the Type 3 contained elements list
+type ContainedElement struct {
+	Type    uint8 `json:"type"` // bit 7 set means the low bits are an SMBIOS structure type
+	Minimum uint8 `json:"minimum"`
+	Maximum uint8 `json:"maximum"`
+}
+
+// ChassisType is the enclosure type, such as Rack Mount Chassis, named as dmidecode names it.
+type ChassisType uint8
+
+var chassisTypeNames = map[ChassisType]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Desktop",
+	0x04: "Low Profile Desktop",
+	0x05: "Pizza Box",
+	0x06: "Mini Tower",
+	0x07: "Tower",
+	0x08: "Portable",
+	0x09: "Laptop",
+	0x0A: "Notebook",
+	0x0B: "Hand Held",
+	0x0C: "Docking Station",
+	0x0D: "All In One",
+	0x0E: "Sub Notebook",
+	0x0F: "Space-saving",
+	0x10: "Lunch Box",
+	0x11: "Main Server Chassis",
+	0x12: "Expansion Chassis",
+	0x13: "Sub Chassis",
+	0x14: "Bus Expansion Chassis",
+	0x15: "Peripheral Chassis",
+	0x16: "RAID Chassis",
+	0x17: "Rack Mount Chassis",
+	0x18: "Sealed-case PC",
+	0x19: "Multi-system",
+	0x1A: "CompactPCI",
+	0x1B: "AdvancedTCA",
+	0x1C: "Blade",
+	0x1D: "Blade Enclosing",
+	0x1E: "Tablet",
+	0x1F: "Convertible",
+	0x20: "Detachable",
+	0x21: "IoT Gateway",
+	0x22: "Embedded PC",
+	0x23: "Mini PC",
+	0x24: "Stick PC",
+}
+
+func (t ChassisType) String() string {
+	return enumName(chassisTypeNames, t)
+}
+
+func (t ChassisType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// ChassisState is the boot-up, power supply or thermal state of the enclosure.
+type ChassisState uint8
+
+var chassisStateNames = map[ChassisState]string{
+	1: "Other",
+	2: "Unknown",
+	3: "Safe",
+	4: "Warning",
+	5: "Critical",
+	6: "Non-recoverable",
+}
+
+func (st ChassisState) String() string {
+	return enumName(chassisStateNames, st)
+}
+
+func (st ChassisState) MarshalText() ([]byte, error) {
+	return []byte(st.String()), nil
+}
+
+// ChassisSecurity is the front panel and keyboard lockout state set by the firmware.
+type ChassisSecurity uint8
+
+var chassisSecurityNames = map[ChassisSecurity]string{
+	1: "Other",
+	2: "Unknown",
+	3: "None",
+	4: "External Interface Locked Out",
+	5: "External Interface Enabled",
+}
+
+func (sec ChassisSecurity) String() string {
+	return enumName(chassisSecurityNames, sec)
+}
+
+func (sec ChassisSecurity) MarshalText() ([]byte, error) {
+	return []byte(sec.String()), nil
+}
+
+// Chassis decodes a Type 3 (System Enclosure or Chassis) structure
+func (s Structure) Chassis() (*ChassisInformation, error) {
+	if err := s.check(3, 0x09); err != nil {
+		return nil, err
+	}
+
+	typ, _ := s.byteAt(0x05)
+	ci := ChassisInformation{
+		Manufacturer:      s.stringAt(0x04),
+		Type:              ChassisType(typ & 0x7F),
+		Lock:              typ&0x80 != 0,
+		Version:           s.stringAt(0x06),
+		SerialNumber:      s.stringAt(0x07),
+		AssetTag:          s.stringAt(0x08),
+		ContainedElements: []ContainedElement{},
+	}
+	bootUp, _ := s.byteAt(0x09)
+	psu, _ := s.byteAt(0x0A)
+	thermal, _ := s.byteAt(0x0B)
+	security, _ := s.byteAt(0x0C)
+	ci.BootUpState = ChassisState(bootUp)
+	ci.PowerSupplyState = ChassisState(psu)
+	ci.ThermalState = ChassisState(thermal)
+	ci.SecurityStatus = ChassisSecurity(security)
+	ci.OEMDefined, _ = s.dword(0x0D)
+	ci.Height, _ = s.byteAt(0x11)
+	ci.NumberPowerCords, _ = s.byteAt(0x12)
+
+	count, _ := s.byteAt(0x13)
+	recLen, ok := s.byteAt(0x14)
+	if !ok {
+		return &ci, nil
+	}
+
+	// n records of m bytes each, every record carries at least type, minimum and maximum
+	if count > 0 && recLen < 3 {
+		return nil, errors.New("type 3 contained element record length too short")
+	}
+	for i := 0; i < int(count); i++ {
+		rec, ok := s.field(0x15+i*int(recLen), int(recLen))
+		if !ok {
+			return nil, errors.New("type 3 contained elements exceed structure length")
+		}
+		ci.ContainedElements = append(ci.ContainedElements, ContainedElement{
+			Type:    rec[0],
+			Minimum: rec[1],
+			Maximum: rec[2],
+		})
+	}
+	ci.SKUNumber = s.stringAt(0x15 + int(count)*int(recLen))
+
+	return &ci, nil
+}
@@ -0,0 +1,35 @@
+package smbios
+
+// CoolingDevice is the decoded Type 27 structure
+type CoolingDevice struct {
+	TemperatureProbe uint16 `json:"temperature_probe"`  // 0xFFFF when there is no associated probe
+	DeviceType       uint8  `json:"device_type"`        // 3 fan, 4 centrifugal blower, 5 chip fan, ...
+	Status           uint8  `json:"status"`             // 3 OK, 4 non-critical, 5 critical, 6 non-recoverable
+	CoolingUnitGroup uint8  `json:"cooling_unit_group"` // 0 when not part of a redundant group
+	OEMDefined       uint32 `json:"oem_defined"`
+	NominalSpeed     uint16 `json:"nominal_speed"` // rpm, 0x8000 is unknown
+	Description      string `json:"description"`   // 2.7+
+}
+
+// CoolingDevice decodes a Type 27 (Cooling Device) structure
+func (s Structure) CoolingDevice() (*CoolingDevice, error) {
+	if err := s.check(27, 0x0C); err != nil {
+		return nil, err
+	}
+
+	cd := CoolingDevice{Description: s.stringAt(0x0E)}
+	cd.TemperatureProbe, _ = s.word(0x04)
+	typeStatus, _ := s.byteAt(0x06)
+	cd.DeviceType = typeStatus & 0x1F
+	cd.Status = typeStatus >> 5
+	cd.CoolingUnitGroup, _ = s.byteAt(0x07)
+	cd.OEMDefined, _ = s.dword(0x08)
+
+	// The nominal speed is optional, absent is the same as unknown
+	var ok bool
+	if cd.NominalSpeed, ok = s.word(0x0C); !ok {
+		cd.NominalSpeed = 0x8000
+	}
+
+	return &cd, nil
+}
@@ -0,0 +1,105 @@
+package smbios
+
+// Undecoded is returned by Decode for structure types without a typed decoder, it carries the raw structure
+type Undecoded struct {
+	Structure
+}
+
+// Decode returns the structure decoded by the typed decoder for its type, such as *BIOSInformation for Type 0 or
+// []string for Type 11, or Undecoded when there is no decoder for the type
+func (s Structure) Decode() (any, error) {
+	var v any
+	var err error
+
+	switch s.Header.Type {
+	case 0:
+		v, err = s.BIOS()
+	case 1:
+		v, err = s.System()
+	case 2:
+		v, err = s.Baseboard()
+	case 3:
+		v, err = s.Chassis()
+	case 4:
+		v, err = s.Processor()
+	case 7:
+		v, err = s.Cache()
+	case 8:
+		v, err = s.PortConnector()
+	case 9:
+		v, err = s.SystemSlot()
+	case 11:
+		v, err = s.OEMStrings()
+	case 12:
+		v, err = s.SystemConfigOptions()
+	case 13:
+		v, err = s.BIOSLanguage()
+	case 14:
+		v, err = s.GroupAssociation()
+	case 15:
+		v, err = s.EventLog()
+	case 16:
+		v, err = s.PhysicalMemoryArray()
+	case 17:
+		v, err = s.MemoryDevice()
+	case 18:
+		v, err = s.MemoryError32()
+	case 19:
+		v, err = s.MemoryArrayMappedAddress()
+	case 20:
+		v, err = s.MemoryDeviceMappedAddress()
+	case 21:
+		v, err = s.PointingDevice()
+	case 22:
+		v, err = s.Battery()
+	case 23:
+		v, err = s.SystemReset()
+	case 24:
+		v, err = s.HardwareSecurity()
+	case 25:
+		v, err = s.PowerControls()
+	case 26:
+		v, err = s.VoltageProbe()
+	case 27:
+		v, err = s.CoolingDevice()
+	case 28:
+		v, err = s.TemperatureProbe()
+	case 29:
+		v, err = s.CurrentProbe()
+	case 30:
+		v, err = s.OutOfBandRemoteAccess()
+	case 32:
+		v, err = s.SystemBoot()
+	case 33:
+		v, err = s.MemoryError64()
+	case 34:
+		v, err = s.ManagementDevice()
+	case 35:
+		v, err = s.ManagementDeviceComponent()
+	case 36:
+		v, err = s.ManagementDeviceThresholdData()
+	case 37:
+		v, err = s.MemoryChannel()
+	case 38:
+		v, err = s.IPMI()
+	case 39:
+		v, err = s.PowerSupply()
+	case 40:
+		v, err = s.AdditionalInformation()
+	case 41:
+		v, err = s.OnboardDeviceExtended()
+	case 42:
+		v, err = s.MCHostInterface()
+	case 43:
+		v, err = s.TPM()
+	default:
+		return Undecoded{Structure: s}, nil
+	}
+
+	// The decoders return a typed nil on error, which would not compare equal to nil as an any
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
@@ -0,0 +1,174 @@
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	entryPointLen   = 0x1F // length of the 32-bit entry point
+	entryPoint64Len = 0x18 // length of the 64-bit (SMBIOS 3.0) entry point
+)
+
+var (
+	anchor             = []byte("_SM_")
+	anchor3            = []byte("_SM3_")
+	intermediateAnchor = []byte("_DMI_")
+)
+
+// EntryPoint holds either the 32-bit (_SM_) or the 64-bit (_SM3_) entry point. Fields that only exist in one of the
+// layouts are left as 0 when the other one is parsed.
+type EntryPoint struct {
+	Anchor                string  `json:"anchor"`              // Anchor string (_SM_ or _SM3_)
+	IntermediateAnchor    string  `json:"intermediate_anchor"` // size of 5 (_DMI_), 32-bit only
+	Checksum              uint8   `json:"checksum"`
+	Length                uint8   `json:"length"`
+	Major                 uint8   `json:"major"`
+	Minor                 uint8   `json:"minor"`
+	Docrev                uint8   `json:"docrev"` // 64-bit only
+	MaxStructureSize      uint16  `json:"max_structure_size"`
+	EntryPointRevision    uint8   `json:"entry_point_revision"` // if this value is 0 then next 5 bytes are set to 0
+	FormattedArea         [5]byte `json:"formatted_area"`       // set to 0 if EntryPointRevision is set to 0
+	IntermediateChecksum  uint8   `json:"intermediate_checksum"`
+	StructureTableLength  uint16  `json:"structure_table_length"`
+	StructureTableMaxSize uint32  `json:"structure_table_max_size"` // 64-bit only
+	StructureTableAddress uint64  `json:"structure_table_address"`  // 32-bit in the _SM_ layout
+	NumberStructures      uint16  `json:"number_structures"`
+	BCDRevision           uint8   `json:"bcd_revision"`
+}
+
+// ChecksumError reports an entry point checksum that does not add up to 0. The entry point is still parsed and
+// returned alongside it so the caller can decide whether to continue.
+type ChecksumError struct {
+	Name string // which checksum failed, "entry point" or "intermediate"
+	Sum  uint8  // sum of the checked bytes, 0 when valid
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("Invalid %s checksum (bytes sum to 0x%02X)", e.Name, e.Sum)
+}
+
+// parseSmbEntryPoint returns the parsed entry point. On a checksum mismatch both the entry point and the
+// *ChecksumError (joined when more than one fails) are returned.
+func parseSmbEntryPoint(smbepf io.Reader) (*EntryPoint, error) {
+	b, err := io.ReadAll(smbepf)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for the 3.0 anchor first, otherwise fall back to the 32-bit layout
+	switch {
+	case bytes.HasPrefix(b, anchor3):
+		return parseEntryPoint64(b)
+	case bytes.HasPrefix(b, anchor):
+		return parseEntryPoint32(b)
+	}
+
+	// If neither Anchor is present then no need to proceed, return error
+	return nil, errors.New("SMBIOS anchor not found (expected _SM_ or _SM3_)")
+}
+
+func parseEntryPoint32(b []byte) (*EntryPoint, error) {
+	// Location index of the checksum byte, and the start of the intermediate area with its own checksum byte
+	const (
+		chksumIdx       int = 4
+		intermediateIdx int = 16
+		intChksumIdx    int = 21
+	)
+
+	if len(b) < entryPointLen {
+		return nil, errors.New("SMBIOS entry point too short")
+	}
+
+	// Caclulate both checksums, a mismatch is reported but does not stop parsing. The intermediate checksum only
+	// covers the _DMI_ area so the index is relative to that sub-slice.
+	chkErr := errors.Join(
+		checksum("entry point", b[chksumIdx], chksumIdx, b),
+		checksum("intermediate", b[intChksumIdx], intChksumIdx-intermediateIdx, b[intermediateIdx:entryPointLen]),
+	)
+
+	ep := EntryPoint{
+		// First 4 bytes is the anchor
+		Anchor:                string(b[0:4]),
+		Checksum:              b[4],
+		Length:                b[5],
+		Major:                 b[6],
+		Minor:                 b[7],
+		MaxStructureSize:      binary.LittleEndian.Uint16(b[8:10]),
+		EntryPointRevision:    b[10],
+		IntermediateAnchor:    string(b[16:21]),
+		IntermediateChecksum:  b[21],
+		StructureTableLength:  binary.LittleEndian.Uint16(b[22:24]),
+		StructureTableAddress: uint64(binary.LittleEndian.Uint32(b[24:28])),
+		NumberStructures:      binary.LittleEndian.Uint16(b[28:30]),
+		BCDRevision:           b[30],
+	}
+	copy(ep.FormattedArea[:], b[11:16])
+
+	return &ep, chkErr
+}
+
+func parseEntryPoint64(b []byte) (*EntryPoint, error) {
+	// Location index of the checksum byte
+	const chksumIdx int = 5
+
+	if len(b) < entryPoint64Len {
+		return nil, errors.New("SMBIOS 3.0 entry point too short")
+	}
+
+	// The checksum covers the number of bytes given by the length field
+	length := int(b[6])
+	if length < entryPoint64Len || length > len(b) {
+		return nil, errors.New("invalid SMBIOS 3.0 entry point length")
+	}
+
+	chkErr := checksum("entry point", b[chksumIdx], chksumIdx, b[:length])
+
+	ep := EntryPoint{
+		// First 5 bytes is the anchor, byte 11 is reserved
+		Anchor:                string(b[0:5]),
+		Checksum:              b[5],
+		Length:                b[6],
+		Major:                 b[7],
+		Minor:                 b[8],
+		Docrev:                b[9],
+		EntryPointRevision:    b[10],
+		StructureTableMaxSize: binary.LittleEndian.Uint32(b[12:16]),
+		StructureTableAddress: binary.LittleEndian.Uint64(b[16:24]),
+	}
+
+	return &ep, chkErr
+}
+
+func checksum(name string, checksum uint8, idx int, b []byte) error {
+	chk := checksum
+	for i := range b {
+		if i == idx {
+			continue
+		}
+		chk += b[i]
+	}
+
+	if chk != 0 {
+		return &ChecksumError{Name: name, Sum: chk}
+	}
+
+	return nil
+}
+
+// Version returns the SMBIOS version as "major.minor"
+func (ep *EntryPoint) Version() string {
+	return fmt.Sprintf("%d.%d", ep.Major, ep.Minor)
+}
+
+// AtLeast reports whether the SMBIOS version is major.minor or later, for fields that were added in later versions
+func (ep *EntryPoint) AtLeast(major, minor uint8) bool {
+	if ep.Major != major {
+		return ep.Major > major
+	}
+
+	return ep.Minor >= minor
+}
@@ -0,0 +1,63 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// SystemEventLog is the decoded Type 15 structure
+type SystemEventLog struct {
+	LogAreaLength       uint16               `json:"log_area_length"`
+	LogHeaderStart      uint16               `json:"log_header_start"`
+	LogDataStart        uint16               `json:"log_data_start"`
+	AccessMethod        uint8                `json:"access_method"`
+	LogStatus           uint8                `json:"log_status"` // bit 0 valid, bit 1 full
+	LogChangeToken      uint32               `json:"log_change_token"`
+	AccessMethodAddress uint32               `json:"access_method_address"`
+	LogHeaderFormat     uint8                `json:"log_header_format"`  // 2.1+
+	NumberDescriptors   uint8                `json:"number_descriptors"` // 2.1+
+	DescriptorLength    uint8                `json:"descriptor_length"`  // 2.1+
+	Descriptors         []EventLogDescriptor `json:"descriptors"`        // 2.1+
+}
+
+// EventLogDescriptor is one entry of the Type 15 supported event log type descriptor list
+type EventLogDescriptor struct {
+	LogType    uint8 `json:"log_type"`
+	DataFormat uint8 `json:"data_format"` // variable data format type
+}
+
+// EventLog decodes a Type 15 (System Event Log) structure
+func (s Structure) EventLog() (*SystemEventLog, error) {
+	if err := s.check(15, 0x14); err != nil {
+		return nil, err
+	}
+
+	el := SystemEventLog{Descriptors: []EventLogDescriptor{}}
+	el.LogAreaLength, _ = s.word(0x04)
+	el.LogHeaderStart, _ = s.word(0x06)
+	el.LogDataStart, _ = s.word(0x08)
+	el.AccessMethod, _ = s.byteAt(0x0A)
+	el.LogStatus, _ = s.byteAt(0x0B)
+	el.LogChangeToken, _ = s.dword(0x0C)
+	el.AccessMethodAddress, _ = s.dword(0x10)
+	el.LogHeaderFormat, _ = s.byteAt(0x14)
+	el.NumberDescriptors, _ = s.byteAt(0x15)
+	el.DescriptorLength, _ = s.byteAt(0x16)
+
+	if el.NumberDescriptors == 0 {
+		return &el, nil
+	}
+
+	// Each descriptor is at least the type and format bytes, any extra bytes are skipped
+	if el.DescriptorLength < 2 {
+		return nil, fmt.Errorf("type 15 descriptor length %d is shorter than 2 bytes", el.DescriptorLength)
+	}
+	list, ok := s.field(0x17, int(el.NumberDescriptors)*int(el.DescriptorLength))
+	if !ok {
+		return nil, fmt.Errorf("type 15 structure too short for %d descriptors of %d bytes", el.NumberDescriptors, el.DescriptorLength)
+	}
+	for i := 0; i < len(list); i += int(el.DescriptorLength) {
+		el.Descriptors = append(el.Descriptors, EventLogDescriptor{LogType: list[i], DataFormat: list[i+1]})
+	}
+
+	return &el, nil
+}
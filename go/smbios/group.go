@@ -0,0 +1,39 @@
+package smbios
+
+// GroupAssociation is the decoded Type 14 structure
+type GroupAssociation struct {
+	GroupName string      `json:"group_name"`
+	Items     []GroupItem `json:"items"`
+}
+
+// GroupItem is one 3 byte member of a Type 14 group
+type GroupItem struct {
+	Type   uint8  `json:"type"` // structure type of the member
+	Handle uint16 `json:"handle"`
+}
+
+// GroupAssociation decodes a Type 14 (Group Associations) structure. The items fill the rest of the formatted area,
+// a partial trailing item is ignored.
+func (s Structure) GroupAssociation() (*GroupAssociation, error) {
+	if err := s.check(14, 0x05); err != nil {
+		return nil, err
+	}
+
+	ga := GroupAssociation{
+		GroupName: s.stringAt(0x04),
+		Items:     []GroupItem{},
+	}
+	for offset := 0x05; ; offset += 3 {
+		typ, ok := s.byteAt(offset)
+		if !ok {
+			break
+		}
+		h, ok := s.word(offset + 1)
+		if !ok {
+			break
+		}
+		ga.Items = append(ga.Items, GroupItem{Type: typ, Handle: h})
+	}
+
+	return &ga, nil
+}
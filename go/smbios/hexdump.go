@@ -0,0 +1,52 @@
+package smbios
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HexDump renders the formatted area as offset, hex and ASCII columns. Offsets start at 04h so they line up with
+// the field offsets in the spec.
+func (s Structure) HexDump() string {
+	return hexDump(s.Formatterd, headerLen)
+}
+
+// HexDump renders any buffer, such as a whole entry point or DMI table, in the same layout with offsets from 0
+func HexDump(b []byte) string {
+	return hexDump(b, 0)
+}
+
+// hexDump renders b 16 bytes per line with offsets starting at base
+func hexDump(b []byte, base int) string {
+	var sb strings.Builder
+	for i := 0; i < len(b); i += 16 {
+		end := i + 16
+		if end > len(b) {
+			end = len(b)
+		}
+		line := b[i:end]
+
+		fmt.Fprintf(&sb, "%04X ", base+i)
+		for j := 0; j < 16; j++ {
+			if j == 8 {
+				sb.WriteByte(' ')
+			}
+			if j < len(line) {
+				fmt.Fprintf(&sb, " %02X", line[j])
+			} else {
+				sb.WriteString("   ")
+			}
+		}
+
+		sb.WriteString("  |")
+		for _, c := range line {
+			if c < 0x20 || c > 0x7E {
+				c = '.'
+			}
+			sb.WriteByte(c)
+		}
+		sb.WriteString("|\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,63 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// ManagementControllerHostInterface is the decoded Type 42 structure, in the 3.2 layout with protocol records
+type ManagementControllerHostInterface struct {
+	InterfaceType uint8            `json:"interface_type"` // 0x40 network host interface, 0xF0 OEM
+	InterfaceData []byte           `json:"interface_data"`
+	Protocols     []ProtocolRecord `json:"protocols"`
+}
+
+// ProtocolRecord is one protocol supported over the host interface
+type ProtocolRecord struct {
+	Type uint8  `json:"type"` // 2 IPMI, 3 MCTP, 4 Redfish over IP, 0xF0 OEM
+	Data []byte `json:"data"`
+}
+
+// MCHostInterface decodes a Type 42 (Management Controller Host Interface) structure. The interface data and each
+// protocol record give their own length, which must stay within the structure.
+func (s Structure) MCHostInterface() (*ManagementControllerHostInterface, error) {
+	if err := s.check(42, 0x06); err != nil {
+		return nil, err
+	}
+
+	hi := ManagementControllerHostInterface{Protocols: []ProtocolRecord{}}
+	hi.InterfaceType, _ = s.byteAt(0x04)
+	n, _ := s.byteAt(0x05)
+	data, ok := s.field(0x06, int(n))
+	if !ok {
+		return nil, fmt.Errorf("type 42 interface data of %d bytes runs past the end of the structure", n)
+	}
+	hi.InterfaceData = append([]byte{}, data...)
+
+	// Older structures end after the interface data
+	offset := 0x06 + int(n)
+	count, ok := s.byteAt(offset)
+	if !ok {
+		return &hi, nil
+	}
+	offset++
+
+	for i := 0; i < int(count); i++ {
+		typ, ok := s.byteAt(offset)
+		if !ok {
+			return nil, fmt.Errorf("type 42 structure too short for %d protocol records, found %d", count, i)
+		}
+		p, ok := s.byteAt(offset + 1)
+		if !ok {
+			return nil, fmt.Errorf("type 42 protocol record %d truncated", i)
+		}
+		data, ok := s.field(offset+2, int(p))
+		if !ok {
+			return nil, fmt.Errorf("type 42 protocol record %d data of %d bytes runs past the end of the structure", i, p)
+		}
+
+		hi.Protocols = append(hi.Protocols, ProtocolRecord{Type: typ, Data: append([]byte{}, data...)})
+		offset += 2 + int(p)
+	}
+
+	return &hi, nil
+}
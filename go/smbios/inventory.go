@@ -0,0 +1,74 @@
+package smbios
+
+import (
+	"errors"
+)
+
+// Identity is the system identity from the Type 1 structure
+type Identity struct {
+	Manufacturer string `json:"manufacturer"`
+	Product      string `json:"product"`
+	Version      string `json:"version"`
+	Serial       string `json:"serial"`
+	UUID         string `json:"uuid"`
+}
+
+// Identity returns the manufacturer, product, version, serial number and UUID from the first Type 1 structure
+func (t *SmTable) Identity() (Identity, error) {
+	ss := t.ByType(1)
+	if len(ss) == 0 {
+		return Identity{}, errors.New("no system information structure")
+	}
+
+	si, err := ss[0].System()
+	if err != nil {
+		return Identity{}, err
+	}
+
+	return Identity{
+		Manufacturer: si.Manufacturer,
+		Product:      si.ProductName,
+		Version:      si.Version,
+		Serial:       si.SerialNumber,
+		UUID:         si.UUID,
+	}, nil
+}
+
+// TotalMemoryBytes returns the installed memory, the sum of the sizes of the populated Type 17 memory devices.
+// Devices of unknown size are skipped.
+func (t *SmTable) TotalMemoryBytes() (uint64, error) {
+	var total uint64
+	for _, s := range t.ByType(17) {
+		md, err := s.MemoryDevice()
+		if err != nil {
+			return 0, err
+		}
+		if md.SizeUnknown {
+			continue
+		}
+		total += md.Size
+	}
+
+	return total, nil
+}
+
+// CPUTotals returns the number of populated processor sockets and the enabled cores and threads across them, from the
+// Type 4 structures. Empty sockets are skipped.
+func (t *SmTable) CPUTotals() (sockets, cores, threads int, err error) {
+	for _, s := range t.ByType(4) {
+		pi, err := s.Processor()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		// Status bit 6 is set when the socket is populated
+		if pi.Status&0x40 == 0 {
+			continue
+		}
+		sockets++
+		cores += int(pi.CoreEnabled)
+		threads += int(pi.ThreadCount)
+	}
+
+	return sockets, cores, threads, nil
+}
@@ -0,0 +1,81 @@
+package smbios
+
+// IPMIDeviceInformation is the decoded Type 38 structure
+type IPMIDeviceInformation struct {
+	InterfaceType           IPMIInterface `json:"interface_type"`
+	SpecMajor               uint8         `json:"spec_major"`
+	SpecMinor               uint8         `json:"spec_minor"`
+	I2CSlaveAddress         uint8         `json:"i2c_slave_address"`
+	NVStorageAddress        uint8         `json:"nv_storage_address"` // 0xFF when not present
+	BaseAddress             uint64        `json:"base_address"`       // I/O flag removed, LS-bit from the modifier applied
+	IOSpace                 bool          `json:"io_space"`           // false means memory mapped
+	BaseAddressModifier     uint8         `json:"base_address_modifier"`
+	RegisterSpacing         uint8         `json:"register_spacing"` // bytes between registers, 0 when reserved
+	InterruptSpecified      bool          `json:"interrupt_specified"`
+	InterruptActiveHigh     bool          `json:"interrupt_active_high"`
+	InterruptLevelTriggered bool          `json:"interrupt_level_triggered"`
+	InterruptNumber         uint8         `json:"interrupt_number"`
+}
+
+// IPMIInterface is the BMC interface type, KCS on most servers.
+type IPMIInterface uint8
+
+var ipmiInterfaceNames = map[IPMIInterface]string{
+	0: "Unknown",
+	1: "KCS (Keyboard Control Style)",
+	2: "SMIC (Server Management Interface Chip)",
+	3: "BT (Block Transfer)",
+	4: "SSIF (SMBus System Interface)",
+}
+
+func (i IPMIInterface) String() string {
+	return enumName(ipmiInterfaceNames, i)
+}
+
+func (i IPMIInterface) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// IPMI decodes a Type 38 (IPMI Device Information) structure
+func (s Structure) IPMI() (*IPMIDeviceInformation, error) {
+	if err := s.check(38, 0x10); err != nil {
+		return nil, err
+	}
+
+	var ipmi IPMIDeviceInformation
+	ifType, _ := s.byteAt(0x04)
+	ipmi.InterfaceType = IPMIInterface(ifType)
+	rev, _ := s.byteAt(0x05)
+	ipmi.SpecMajor = rev >> 4
+	ipmi.SpecMinor = rev & 0x0F
+	ipmi.I2CSlaveAddress, _ = s.byteAt(0x06)
+	ipmi.NVStorageAddress, _ = s.byteAt(0x07)
+
+	// Bit 0 of the base address selects I/O space over memory mapped
+	base, _ := s.qword(0x08)
+	ipmi.IOSpace = base&0x01 != 0
+	ipmi.BaseAddress = base &^ 0x01
+
+	mod, ok := s.byteAt(0x10)
+	if !ok {
+		return &ipmi, nil
+	}
+	ipmi.BaseAddressModifier = mod
+	ipmi.BaseAddress |= uint64(mod>>4) & 0x01
+
+	// Bits 7:6 are the register spacing
+	switch mod >> 6 {
+	case 0:
+		ipmi.RegisterSpacing = 1
+	case 1:
+		ipmi.RegisterSpacing = 4
+	case 2:
+		ipmi.RegisterSpacing = 16
+	}
+	ipmi.InterruptSpecified = mod&0x08 != 0
+	ipmi.InterruptActiveHigh = mod&0x02 != 0
+	ipmi.InterruptLevelTriggered = mod&0x01 != 0
+	ipmi.InterruptNumber, _ = s.byteAt(0x11)
+
+	return &ipmi, nil
+}
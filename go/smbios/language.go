@@ -0,0 +1,34 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// BIOSLanguage is the decoded Type 13 structure
+type BIOSLanguage struct {
+	InstallableLanguages uint8    `json:"installable_languages"`
+	Abbreviated          bool     `json:"abbreviated"` // 2.1+, languages use the short enUS format instead of en|US|iso8859-1
+	CurrentLanguage      string   `json:"current_language"`
+	Languages            []string `json:"languages"`
+}
+
+// BIOSLanguage decodes a Type 13 (BIOS Language Information) structure
+func (s Structure) BIOSLanguage() (*BIOSLanguage, error) {
+	if err := s.check(13, 0x16); err != nil {
+		return nil, err
+	}
+
+	var bl BIOSLanguage
+	bl.InstallableLanguages, _ = s.byteAt(0x04)
+	flags, _ := s.byteAt(0x05)
+	bl.Abbreviated = flags&0x01 != 0
+	bl.CurrentLanguage = s.stringAt(0x15)
+
+	// Each installable language is one string
+	if int(bl.InstallableLanguages) > len(s.Strings) {
+		return nil, fmt.Errorf("type 13 count %d exceeds the %d strings present", bl.InstallableLanguages, len(s.Strings))
+	}
+	bl.Languages = append([]string{}, s.Strings[:bl.InstallableLanguages]...)
+
+	return &bl, nil
+}
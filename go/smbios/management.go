@@ -0,0 +1,111 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// ManagementDevice is the decoded Type 34 structure
+type ManagementDevice struct {
+	Description string `json:"description"`
+	Type        uint8  `json:"type"` // 3 National Semiconductor LM75, ..., 0x0D Maxim 1617
+	Address     uint32 `json:"address"`
+	AddressType uint8  `json:"address_type"` // 3 I/O port, 4 memory, 5 SM bus
+}
+
+// ManagementDeviceComponent is the decoded Type 35 structure, linking a management device to the probe or cooling
+// device it monitors and to its thresholds
+type ManagementDeviceComponent struct {
+	Description            string `json:"description"`
+	ManagementDeviceHandle uint16 `json:"management_device_handle"` // Type 34
+	ComponentHandle        uint16 `json:"component_handle"`         // Type 26, 27, 28 or 29
+	ThresholdHandle        uint16 `json:"threshold_handle"`         // Type 36, 0xFFFF when there is none
+}
+
+// ManagementDeviceThresholdData is the decoded Type 36 structure. Thresholds that are not available (0x8000) are
+// nil, the units are those of the component being monitored.
+type ManagementDeviceThresholdData struct {
+	LowerNonCritical    *int16 `json:"lower_non_critical"`
+	UpperNonCritical    *int16 `json:"upper_non_critical"`
+	LowerCritical       *int16 `json:"lower_critical"`
+	UpperCritical       *int16 `json:"upper_critical"`
+	LowerNonRecoverable *int16 `json:"lower_non_recoverable"`
+	UpperNonRecoverable *int16 `json:"upper_non_recoverable"`
+}
+
+// ManagementDevice decodes a Type 34 (Management Device) structure
+func (s Structure) ManagementDevice() (*ManagementDevice, error) {
+	if err := s.check(34, 0x0B); err != nil {
+		return nil, err
+	}
+
+	md := ManagementDevice{Description: s.stringAt(0x04)}
+	md.Type, _ = s.byteAt(0x05)
+	md.Address, _ = s.dword(0x06)
+	md.AddressType, _ = s.byteAt(0x0A)
+
+	return &md, nil
+}
+
+// ManagementDeviceComponent decodes a Type 35 (Management Device Component) structure
+func (s Structure) ManagementDeviceComponent() (*ManagementDeviceComponent, error) {
+	if err := s.check(35, 0x09); err != nil {
+		return nil, err
+	}
+
+	mc := ManagementDeviceComponent{Description: s.stringAt(0x04)}
+	mc.ManagementDeviceHandle, _ = s.word(0x05)
+	mc.ComponentHandle, _ = s.word(0x07)
+
+	// The threshold handle is optional, absent is the same as none
+	var ok bool
+	if mc.ThresholdHandle, ok = s.word(0x09); !ok {
+		mc.ThresholdHandle = 0xFFFF
+	}
+
+	return &mc, nil
+}
+
+// ManagementDeviceThresholdData decodes a Type 36 (Management Device Threshold Data) structure
+func (s Structure) ManagementDeviceThresholdData() (*ManagementDeviceThresholdData, error) {
+	if err := s.check(36, 0x10); err != nil {
+		return nil, err
+	}
+
+	td := ManagementDeviceThresholdData{
+		LowerNonCritical:    s.probeSigned(0x04),
+		UpperNonCritical:    s.probeSigned(0x06),
+		LowerCritical:       s.probeSigned(0x08),
+		UpperCritical:       s.probeSigned(0x0A),
+		LowerNonRecoverable: s.probeSigned(0x0C),
+		UpperNonRecoverable: s.probeSigned(0x0E),
+	}
+
+	return &td, nil
+}
+
+// ManagementLinks follows the handles of a management device component to the management device and the threshold
+// data. The threshold data is nil when the component has none.
+func (t *SmTable) ManagementLinks(c *ManagementDeviceComponent) (*ManagementDevice, *ManagementDeviceThresholdData, error) {
+	s, ok := t.ByHandle(c.ManagementDeviceHandle)
+	if !ok {
+		return nil, nil, fmt.Errorf("management device 0x%04X not found", c.ManagementDeviceHandle)
+	}
+	md, err := s.ManagementDevice()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.ThresholdHandle == 0xFFFF {
+		return md, nil, nil
+	}
+	s, ok = t.ByHandle(c.ThresholdHandle)
+	if !ok {
+		return nil, nil, fmt.Errorf("threshold data 0x%04X not found", c.ThresholdHandle)
+	}
+	td, err := s.ManagementDeviceThresholdData()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return md, td, nil
+}
@@ -0,0 +1,156 @@
+package smbios
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"sync"
+)
+
+const (
+	// The entry point lives on a 16 byte boundary in the legacy BIOS area
+	memScanStart = 0xF0000
+	memScanEnd   = 0x100000
+
+	// Upper bound on the table read from physical memory, the 3.0 max size is only a maximum
+	maxMemTableLen = 1 << 20
+)
+
+// ReadMem locates the entry point in physical memory, such as /dev/mem, and returns the raw entry point and DMI table
+// bytes. This is a fallback for systems that do not expose the tables in sysfs and requires root.
+func ReadMem(path string) (entry []byte, table []byte, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, permissionError(err)
+	}
+	defer f.Close()
+
+	return readMem(f)
+}
+
+func readMem(mem io.ReaderAt) ([]byte, []byte, error) {
+	region := make([]byte, memScanEnd-memScanStart)
+	if _, err := mem.ReadAt(region, memScanStart); err != nil {
+		return nil, nil, fmt.Errorf("unable to read BIOS memory area: %w", err)
+	}
+
+	entry, err := findEntryPoint(region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	table, err := readMemTable(mem, entry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entry, table, nil
+}
+
+// readMemTable reads the structure table the entry point refers to
+func readMemTable(mem io.ReaderAt, entry []byte) ([]byte, error) {
+	ep, err := parseSmbEntryPoint(bytes.NewReader(entry))
+	var chkErr *ChecksumError
+	if err != nil && !errors.As(err, &chkErr) {
+		return nil, err
+	}
+
+	// The 3.0 entry point only gives a maximum size, the end-of-table structure stops the parser before it
+	length := uint64(ep.StructureTableLength)
+	if ep.Anchor == string(anchor3) {
+		length = uint64(ep.StructureTableMaxSize)
+	}
+	if length > maxMemTableLen {
+		length = maxMemTableLen
+	}
+	if ep.StructureTableAddress > math.MaxInt64-length {
+		return nil, errors.New("structure table address out of range")
+	}
+
+	table := make([]byte, length)
+	n, err := mem.ReadAt(table, int64(ep.StructureTableAddress))
+	if err != nil && !(errors.Is(err, io.EOF) && n > 0) {
+		return nil, fmt.Errorf("unable to read structure table: %w", err)
+	}
+
+	return table[:n], nil
+}
+
+// findEntryPoint scans the region on 16 byte boundaries and returns the entry point bytes, trimmed to the length it
+// reports. The 64-bit entry point is preferred when both are present.
+func findEntryPoint(region []byte) ([]byte, error) {
+	var entry []byte
+	for off := 0; off+entryPoint64Len <= len(region); off += 16 {
+		b := region[off:]
+		switch {
+		case bytes.HasPrefix(b, anchor3):
+			if length := int(b[6]); length >= entryPoint64Len && length <= len(b) {
+				return b[:length], nil
+			}
+		case bytes.HasPrefix(b, anchor) && entry == nil:
+			if length := int(b[5]); length >= entryPointLen && length <= len(b) {
+				entry = b[:length]
+			}
+		}
+	}
+
+	if entry == nil {
+		return nil, fmt.Errorf("%w: anchor not found in memory", ErrNoSMBIOS)
+	}
+
+	return entry, nil
+}
+
+// MemSource reads the tables from physical memory, see ReadMem. When EntryPointPath names an entry point that exists,
+// such as the sysfs one on a kernel that exposes it without the DMI table, the table is read from the address it gives
+// and memory is not scanned, which also finds tables outside the legacy BIOS area on UEFI systems. Memory is read once,
+// on first use, so both methods return the same snapshot.
+type MemSource struct {
+	Path           string
+	EntryPointPath string
+
+	once  sync.Once
+	entry []byte
+	table []byte
+	err   error
+}
+
+func (m *MemSource) EntryPoint() ([]byte, error) {
+	m.once.Do(m.read)
+	return m.entry, m.err
+}
+
+func (m *MemSource) Table() ([]byte, error) {
+	m.once.Do(m.read)
+	return m.table, m.err
+}
+
+func (m *MemSource) read() {
+	var entry []byte
+	if m.EntryPointPath != "" {
+		b, err := os.ReadFile(m.EntryPointPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			m.err = permissionError(err)
+			return
+		}
+		entry = b
+	}
+
+	f, err := os.Open(m.Path)
+	if err != nil {
+		m.err = permissionError(err)
+		return
+	}
+	defer f.Close()
+
+	if entry == nil {
+		m.entry, m.table, m.err = readMem(f)
+		return
+	}
+	m.entry = entry
+	m.table, m.err = readMemTable(f, entry)
+}
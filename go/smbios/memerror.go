@@ -0,0 +1,118 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// MemoryError32 is the decoded Type 18 structure. Addresses and resolution that firmware reports as unknown
+// (0x80000000) are nil.
+type MemoryError32 struct {
+	ErrorType          uint8   `json:"error_type"`        // 3 OK, 4 bad read, 5 parity, 6 single-bit, 7 double-bit, ...
+	ErrorGranularity   uint8   `json:"error_granularity"` // 3 device level, 4 memory partition level
+	ErrorOperation     uint8   `json:"error_operation"`   // 3 read, 4 write, 5 partial write
+	VendorSyndrome     uint32  `json:"vendor_syndrome"`   // 0 is unknown
+	ArrayErrorAddress  *uint32 `json:"array_error_address"`
+	DeviceErrorAddress *uint32 `json:"device_error_address"`
+	ErrorResolution    *uint32 `json:"error_resolution"` // bytes
+}
+
+// MemoryError64 is the decoded Type 33 structure, Type 18 with 64-bit addresses. Addresses that firmware reports as
+// unknown (0x8000000000000000) and an unknown resolution (0x80000000) are nil.
+type MemoryError64 struct {
+	ErrorType          uint8   `json:"error_type"`
+	ErrorGranularity   uint8   `json:"error_granularity"`
+	ErrorOperation     uint8   `json:"error_operation"`
+	VendorSyndrome     uint32  `json:"vendor_syndrome"`
+	ArrayErrorAddress  *uint64 `json:"array_error_address"`
+	DeviceErrorAddress *uint64 `json:"device_error_address"`
+	ErrorResolution    *uint32 `json:"error_resolution"` // bytes
+}
+
+// MemoryError32 decodes a Type 18 (32-Bit Memory Error Information) structure
+func (s Structure) MemoryError32() (*MemoryError32, error) {
+	if err := s.check(18, 0x17); err != nil {
+		return nil, err
+	}
+
+	me := MemoryError32{
+		ArrayErrorAddress:  s.knownDword(0x0B),
+		DeviceErrorAddress: s.knownDword(0x0F),
+		ErrorResolution:    s.knownDword(0x13),
+	}
+	me.ErrorType, _ = s.byteAt(0x04)
+	me.ErrorGranularity, _ = s.byteAt(0x05)
+	me.ErrorOperation, _ = s.byteAt(0x06)
+	me.VendorSyndrome, _ = s.dword(0x07)
+
+	return &me, nil
+}
+
+// MemoryError64 decodes a Type 33 (64-Bit Memory Error Information) structure
+func (s Structure) MemoryError64() (*MemoryError64, error) {
+	if err := s.check(33, 0x1F); err != nil {
+		return nil, err
+	}
+
+	me := MemoryError64{
+		ArrayErrorAddress:  s.knownQword(0x0B),
+		DeviceErrorAddress: s.knownQword(0x13),
+		ErrorResolution:    s.knownDword(0x1B),
+	}
+	me.ErrorType, _ = s.byteAt(0x04)
+	me.ErrorGranularity, _ = s.byteAt(0x05)
+	me.ErrorOperation, _ = s.byteAt(0x06)
+	me.VendorSyndrome, _ = s.dword(0x07)
+
+	return &me, nil
+}
+
+// knownDword returns the dword at offset, nil when it is the 0x80000000 unknown value
+func (s Structure) knownDword(offset int) *uint32 {
+	v, ok := s.dword(offset)
+	if !ok || v == 0x80000000 {
+		return nil
+	}
+
+	return &v
+}
+
+// knownQword returns the qword at offset, nil when it is the 0x8000000000000000 unknown value
+func (s Structure) knownQword(offset int) *uint64 {
+	v, ok := s.qword(offset)
+	if !ok || v == 0x8000000000000000 {
+		return nil
+	}
+
+	return &v
+}
+
+// MemoryErrorFor follows the error information handle of a memory device to its Type 18 or Type 33 record and returns
+// it decoded, as a *MemoryError32 or a *MemoryError64. It returns nil when the device has no record, the 0xFFFE not
+// provided and 0xFFFF no error handles.
+func (t *SmTable) MemoryErrorFor(md *MemoryDevice) (any, error) {
+	h := md.ErrorInfoHandle
+	if h == 0xFFFE || h == 0xFFFF {
+		return nil, nil
+	}
+
+	s, ok := t.ByHandle(h)
+	if !ok {
+		return nil, fmt.Errorf("memory error information 0x%04X not found", h)
+	}
+	// The decoders return a typed nil on error, which would not compare equal to nil as an any
+	var v any
+	var err error
+	switch s.Header.Type {
+	case 18:
+		v, err = s.MemoryError32()
+	case 33:
+		v, err = s.MemoryError64()
+	default:
+		return nil, fmt.Errorf("structure 0x%04X is type %d, not memory error information", h, s.Header.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
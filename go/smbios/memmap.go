@@ -0,0 +1,66 @@
+package smbios
+
+// MemoryArrayMappedAddress is the decoded Type 19 structure
+type MemoryArrayMappedAddress struct {
+	StartingAddress   uint64 `json:"starting_address"` // bytes
+	EndingAddress     uint64 `json:"ending_address"`   // bytes, address of the last byte in the range
+	MemoryArrayHandle uint16 `json:"memory_array_handle"`
+	PartitionWidth    uint8  `json:"partition_width"` // number of memory devices that form a single row
+}
+
+// MemoryArrayMappedAddress decodes a Type 19 (Memory Array Mapped Address) structure
+func (s Structure) MemoryArrayMappedAddress() (*MemoryArrayMappedAddress, error) {
+	if err := s.check(19, 0x0F); err != nil {
+		return nil, err
+	}
+
+	var ma MemoryArrayMappedAddress
+	ma.MemoryArrayHandle, _ = s.word(0x0C)
+	ma.PartitionWidth, _ = s.byteAt(0x0E)
+	ma.StartingAddress, ma.EndingAddress = s.mappedRange(0x04, 0x0F)
+
+	return &ma, nil
+}
+
+// MemoryDeviceMappedAddress is the decoded Type 20 structure
+type MemoryDeviceMappedAddress struct {
+	StartingAddress             uint64 `json:"starting_address"` // bytes
+	EndingAddress               uint64 `json:"ending_address"`   // bytes, address of the last byte in the range
+	MemoryDeviceHandle          uint16 `json:"memory_device_handle"`
+	MemoryArrayMappedAddrHandle uint16 `json:"memory_array_mapped_address_handle"`
+	PartitionRowPosition        uint8  `json:"partition_row_position"` // 0xFF unknown
+	InterleavePosition          uint8  `json:"interleave_position"`    // 0 not interleaved, 0xFF unknown
+	InterleavedDataDepth        uint8  `json:"interleaved_data_depth"` // 0 not interleaved, 0xFF unknown
+}
+
+// MemoryDeviceMappedAddress decodes a Type 20 (Memory Device Mapped Address) structure
+func (s Structure) MemoryDeviceMappedAddress() (*MemoryDeviceMappedAddress, error) {
+	if err := s.check(20, 0x13); err != nil {
+		return nil, err
+	}
+
+	var md MemoryDeviceMappedAddress
+	md.MemoryDeviceHandle, _ = s.word(0x0C)
+	md.MemoryArrayMappedAddrHandle, _ = s.word(0x0E)
+	md.PartitionRowPosition, _ = s.byteAt(0x10)
+	md.InterleavePosition, _ = s.byteAt(0x11)
+	md.InterleavedDataDepth, _ = s.byteAt(0x12)
+	md.StartingAddress, md.EndingAddress = s.mappedRange(0x04, 0x13)
+
+	return &md, nil
+}
+
+// mappedRange decodes a pair of 32-bit starting and ending addresses in KB at offset, escaping to the 2.7+ pair of
+// 64-bit byte addresses at ext when the starting address is 0xFFFFFFFF
+func (s Structure) mappedRange(offset, ext int) (uint64, uint64) {
+	start, _ := s.dword(offset)
+	end, _ := s.dword(offset + 4)
+	if start == 0xFFFFFFFF {
+		extStart, _ := s.qword(ext)
+		extEnd, _ := s.qword(ext + 8)
+		return extStart, extEnd
+	}
+
+	// The ending address is the last KB in the range
+	return uint64(start) << 10, (uint64(end)+1)<<10 - 1
+}
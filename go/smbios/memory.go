@@ -0,0 +1,258 @@
+package smbios
+
+// MemoryDevice is the decoded Type 17 structure
+type MemoryDevice struct {
+	PhysicalArrayHandle uint16     `json:"physical_array_handle"`
+	ErrorInfoHandle     uint16     `json:"error_info_handle"`
+	TotalWidth          uint16     `json:"total_width"` // bits, 0xFFFF is unknown
+	DataWidth           uint16     `json:"data_width"`  // bits, 0xFFFF is unknown
+	Size                uint64     `json:"size"`        // bytes, 0 when the slot is empty
+	SizeUnknown         bool       `json:"size_unknown"`
+	FormFactor          FormFactor `json:"form_factor"`
+	DeviceSet           uint8      `json:"device_set"`
+	DeviceLocator       string     `json:"device_locator"`
+	BankLocator         string     `json:"bank_locator"`
+	Type                MemoryType `json:"type"`
+	TypeDetail          uint16     `json:"type_detail"`
+	Speed               uint16     `json:"speed"`            // 2.3+, MT/s
+	Manufacturer        string     `json:"manufacturer"`     // 2.3+
+	SerialNumber        string     `json:"serial_number"`    // 2.3+
+	AssetTag            string     `json:"asset_tag"`        // 2.3+
+	PartNumber          string     `json:"part_number"`      // 2.3+
+	ConfiguredSpeed     uint16     `json:"configured_speed"` // 2.7+, MT/s
+}
+
+// FormFactor is the physical package of a memory device. It renders as its spec name, in JSON as well.
+type FormFactor uint8
+
+var formFactorNames = map[FormFactor]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "SIMM",
+	0x04: "SIP",
+	0x05: "Chip",
+	0x06: "DIP",
+	0x07: "ZIP",
+	0x08: "Proprietary Card",
+	0x09: "DIMM",
+	0x0A: "TSOP",
+	0x0B: "Row of chips",
+	0x0C: "RIMM",
+	0x0D: "SODIMM",
+	0x0E: "SRIMM",
+	0x0F: "FB-DIMM",
+	0x10: "Die", // 3.3+
+}
+
+func (f FormFactor) String() string {
+	return enumName(formFactorNames, f)
+}
+
+func (f FormFactor) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// MemoryType is the memory technology of a memory device. It renders as its spec name, in JSON as well.
+type MemoryType uint8
+
+var memoryTypeNames = map[MemoryType]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "DRAM",
+	0x04: "EDRAM",
+	0x05: "VRAM",
+	0x06: "SRAM",
+	0x07: "RAM",
+	0x08: "ROM",
+	0x09: "Flash",
+	0x0A: "EEPROM",
+	0x0B: "FEPROM",
+	0x0C: "EPROM",
+	0x0D: "CDRAM",
+	0x0E: "3DRAM",
+	0x0F: "SDRAM",
+	0x10: "SGRAM",
+	0x11: "RDRAM",
+	0x12: "DDR",
+	0x13: "DDR2",
+	0x14: "DDR2 FB-DIMM",
+	0x18: "DDR3",
+	0x19: "FBD2",
+	0x1A: "DDR4",
+	0x1B: "LPDDR",
+	0x1C: "LPDDR2",
+	0x1D: "LPDDR3",
+	0x1E: "LPDDR4",
+	0x1F: "Logical non-volatile device",
+	0x20: "HBM",    // 3.3+
+	0x21: "HBM2",   // 3.3+
+	0x22: "DDR5",   // 3.3+
+	0x23: "LPDDR5", // 3.3+
+}
+
+func (m MemoryType) String() string {
+	return enumName(memoryTypeNames, m)
+}
+
+func (m MemoryType) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// SizeMB returns the device size normalized to MB
+func (m *MemoryDevice) SizeMB() uint64 {
+	return m.Size >> 20
+}
+
+// MemoryDevice decodes a Type 17 (Memory Device) structure
+func (s Structure) MemoryDevice() (*MemoryDevice, error) {
+	if err := s.check(17, 0x15); err != nil {
+		return nil, err
+	}
+
+	md := MemoryDevice{
+		DeviceLocator: s.stringAt(0x10),
+		BankLocator:   s.stringAt(0x11),
+		Manufacturer:  s.stringAt(0x17),
+		SerialNumber:  s.stringAt(0x18),
+		AssetTag:      s.stringAt(0x19),
+		PartNumber:    s.stringAt(0x1A),
+	}
+	md.PhysicalArrayHandle, _ = s.word(0x04)
+	md.ErrorInfoHandle, _ = s.word(0x06)
+	md.TotalWidth, _ = s.word(0x08)
+	md.DataWidth, _ = s.word(0x0A)
+	formFactor, _ := s.byteAt(0x0E)
+	md.FormFactor = FormFactor(formFactor)
+	md.DeviceSet, _ = s.byteAt(0x0F)
+	typ, _ := s.byteAt(0x12)
+	md.Type = MemoryType(typ)
+	md.TypeDetail, _ = s.word(0x13)
+	md.Speed, _ = s.word(0x15)
+	md.ConfiguredSpeed, _ = s.word(0x20)
+
+	// 0x7FFF means the size is in the 2.7+ extended size field, always in MB
+	size, _ := s.word(0x0C)
+	ext, hasExt := s.dword(0x1C)
+	switch {
+	case size == 0xFFFF:
+		md.SizeUnknown = true
+	case size == 0x7FFF && hasExt:
+		md.Size = uint64(ext&0x7FFFFFFF) << 20
+	case size&0x8000 != 0:
+		// Granularity bit set, value is in KB
+		md.Size = uint64(size&0x7FFF) << 10
+	default:
+		md.Size = uint64(size) << 20
+	}
+
+	return &md, nil
+}
+
+// PhysicalMemoryArray is the decoded Type 16 structure
+type PhysicalMemoryArray struct {
+	Location        MemoryArrayLocation `json:"location"`
+	Use             MemoryArrayUse      `json:"use"`
+	ErrorCorrection ErrorCorrection     `json:"error_correction"`
+	MaximumCapacity uint64              `json:"maximum_capacity"` // bytes
+	ErrorInfoHandle uint16              `json:"error_info_handle"`
+	NumberDevices   uint16              `json:"number_devices"` // number of slots or sockets
+}
+
+// MemoryArrayLocation is where the memory array is, the System Board Or Motherboard for most arrays.
+type MemoryArrayLocation uint8
+
+var memoryArrayLocationNames = map[MemoryArrayLocation]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "System Board Or Motherboard",
+	0x04: "ISA Add-on Card",
+	0x05: "EISA Add-on Card",
+	0x06: "PCI Add-on Card",
+	0x07: "MCA Add-on Card",
+	0x08: "PCMCIA Add-on Card",
+	0x09: "Proprietary Add-on Card",
+	0x0A: "NuBus",
+	0xA0: "PC-98/C20 Add-on Card",
+	0xA1: "PC-98/C24 Add-on Card",
+	0xA2: "PC-98/E Add-on Card",
+	0xA3: "PC-98/Local Bus Add-on Card",
+}
+
+func (l MemoryArrayLocation) String() string {
+	return enumName(memoryArrayLocationNames, l)
+}
+
+func (l MemoryArrayLocation) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// MemoryArrayUse is what the memory array is for.
+type MemoryArrayUse uint8
+
+var memoryArrayUseNames = map[MemoryArrayUse]string{
+	1: "Other",
+	2: "Unknown",
+	3: "System Memory",
+	4: "Video Memory",
+	5: "Flash Memory",
+	6: "Non-volatile RAM",
+	7: "Cache Memory",
+}
+
+func (u MemoryArrayUse) String() string {
+	return enumName(memoryArrayUseNames, u)
+}
+
+func (u MemoryArrayUse) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// ErrorCorrection is the error detection or correction scheme of a memory array, and of a cache with the same
+// values.
+type ErrorCorrection uint8
+
+var errorCorrectionNames = map[ErrorCorrection]string{
+	1: "Other",
+	2: "Unknown",
+	3: "None",
+	4: "Parity",
+	5: "Single-bit ECC",
+	6: "Multi-bit ECC",
+	7: "CRC",
+}
+
+func (e ErrorCorrection) String() string {
+	return enumName(errorCorrectionNames, e)
+}
+
+func (e ErrorCorrection) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// PhysicalMemoryArray decodes a Type 16 (Physical Memory Array) structure
+func (s Structure) PhysicalMemoryArray() (*PhysicalMemoryArray, error) {
+	if err := s.check(16, 0x0F); err != nil {
+		return nil, err
+	}
+
+	var pa PhysicalMemoryArray
+	loc, _ := s.byteAt(0x04)
+	use, _ := s.byteAt(0x05)
+	ecc, _ := s.byteAt(0x06)
+	pa.Location = MemoryArrayLocation(loc)
+	pa.Use = MemoryArrayUse(use)
+	pa.ErrorCorrection = ErrorCorrection(ecc)
+	pa.ErrorInfoHandle, _ = s.word(0x0B)
+	pa.NumberDevices, _ = s.word(0x0D)
+
+	// 0x80000000 means the capacity is in the 2.7+ extended field, given in bytes
+	capacity, _ := s.dword(0x07)
+	if ext, ok := s.qword(0x0F); ok && capacity == 0x80000000 {
+		pa.MaximumCapacity = ext
+	} else {
+		// Capacity is in KB
+		pa.MaximumCapacity = uint64(capacity) << 10
+	}
+
+	return &pa, nil
+}
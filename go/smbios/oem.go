@@ -0,0 +1,31 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// OEMStrings decodes a Type 11 (OEM Strings) structure. The formatted area only holds the count, the strings
+// themselves are the string table.
+func (s Structure) OEMStrings() ([]string, error) {
+	return s.countedStrings(11)
+}
+
+// SystemConfigOptions decodes a Type 12 (System Configuration Options) structure, typically jumper and switch
+// settings. Like Type 11 the formatted area only holds the count.
+func (s Structure) SystemConfigOptions() ([]string, error) {
+	return s.countedStrings(12)
+}
+
+// countedStrings returns the first count strings, count being the only byte of the formatted area
+func (s Structure) countedStrings(typ uint8) ([]string, error) {
+	if err := s.check(typ, 0x05); err != nil {
+		return nil, err
+	}
+
+	count, _ := s.byteAt(0x04)
+	if int(count) > len(s.Strings) {
+		return nil, fmt.Errorf("type %d count %d exceeds the %d strings present", typ, count, len(s.Strings))
+	}
+
+	return append([]string{}, s.Strings[:count]...), nil
+}
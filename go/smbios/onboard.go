@@ -0,0 +1,33 @@
+package smbios
+
+// OnboardDeviceExtended is the decoded Type 41 structure
+type OnboardDeviceExtended struct {
+	ReferenceDesignation string `json:"reference_designation"`
+	DeviceType           uint8  `json:"device_type"` // 3 video, 5 ethernet, 8 SAS controller, ...
+	Enabled              bool   `json:"enabled"`
+	DeviceTypeInstance   uint8  `json:"device_type_instance"`
+	SegmentGroup         uint16 `json:"segment_group"`
+	BusNumber            uint8  `json:"bus_number"`
+	DeviceNumber         uint8  `json:"device_number"`
+	FunctionNumber       uint8  `json:"function_number"`
+}
+
+// OnboardDeviceExtended decodes a Type 41 (Onboard Devices Extended Information) structure
+func (s Structure) OnboardDeviceExtended() (*OnboardDeviceExtended, error) {
+	if err := s.check(41, 0x0B); err != nil {
+		return nil, err
+	}
+
+	od := OnboardDeviceExtended{ReferenceDesignation: s.stringAt(0x04)}
+	typ, _ := s.byteAt(0x05)
+	od.DeviceType = typ & 0x7F
+	od.Enabled = typ&0x80 != 0
+	od.DeviceTypeInstance, _ = s.byteAt(0x06)
+	od.SegmentGroup, _ = s.word(0x07)
+	od.BusNumber, _ = s.byteAt(0x09)
+	devFn, _ := s.byteAt(0x0A)
+	od.DeviceNumber = devFn >> 3
+	od.FunctionNumber = devFn & 0x07
+
+	return &od, nil
+}
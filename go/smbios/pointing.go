@@ -0,0 +1,22 @@
+package smbios
+
+// PointingDevice is the decoded Type 21 structure
+type PointingDevice struct {
+	Type      uint8 `json:"type"`      // 3 mouse, 4 track ball, 5 track point, 6 glide point, 7 touch pad, ...
+	Interface uint8 `json:"interface"` // 3 serial, 4 PS/2, 7 bus mouse, 8 ADB, 0xA0 DB-9, ...
+	Buttons   uint8 `json:"buttons"`
+}
+
+// PointingDevice decodes a Type 21 (Built-in Pointing Device) structure
+func (s Structure) PointingDevice() (*PointingDevice, error) {
+	if err := s.check(21, 0x07); err != nil {
+		return nil, err
+	}
+
+	var pd PointingDevice
+	pd.Type, _ = s.byteAt(0x04)
+	pd.Interface, _ = s.byteAt(0x05)
+	pd.Buttons, _ = s.byteAt(0x06)
+
+	return &pd, nil
+}
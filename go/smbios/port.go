@@ -0,0 +1,137 @@
+package smbios
+
+// PortConnector is the decoded Type 8 structure
+type PortConnector struct {
+	InternalDesignator    string            `json:"internal_designator"`
+	InternalConnectorType PortConnectorType `json:"internal_connector_type"`
+	ExternalDesignator    string            `json:"external_designator"`
+	ExternalConnectorType PortConnectorType `json:"external_connector_type"`
+	PortType              PortType          `json:"port_type"`
+}
+
+// PortConnectorType is the physical connector on either side of a port, None when that side has no connector.
+type PortConnectorType uint8
+
+var portConnectorTypeNames = map[PortConnectorType]string{
+	0x00: "None",
+	0x01: "Centronics",
+	0x02: "Mini Centronics",
+	0x03: "Proprietary",
+	0x04: "DB-25 male",
+	0x05: "DB-25 female",
+	0x06: "DB-15 male",
+	0x07: "DB-15 female",
+	0x08: "DB-9 male",
+	0x09: "DB-9 female",
+	0x0A: "RJ-11",
+	0x0B: "RJ-45",
+	0x0C: "50 Pin MiniSCSI",
+	0x0D: "Mini DIN",
+	0x0E: "Micro DIN",
+	0x0F: "PS/2",
+	0x10: "Infrared",
+	0x11: "HP-HIL",
+	0x12: "Access Bus (USB)",
+	0x13: "SSA SCSI",
+	0x14: "Circular DIN-8 male",
+	0x15: "Circular DIN-8 female",
+	0x16: "On Board IDE",
+	0x17: "On Board Floppy",
+	0x18: "9 Pin Dual Inline (pin 10 cut)",
+	0x19: "25 Pin Dual Inline (pin 26 cut)",
+	0x1A: "50 Pin Dual Inline",
+	0x1B: "68 Pin Dual Inline",
+	0x1C: "On Board Sound Input From CD-ROM",
+	0x1D: "Mini Centronics Type-14",
+	0x1E: "Mini Centronics Type-26",
+	0x1F: "Mini Jack (headphones)",
+	0x20: "BNC",
+	0x21: "IEEE 1394",
+	0x22: "SAS/SATA Plug Receptacle",
+	0x23: "USB Type-C Receptacle",
+	0xA0: "PC-98",
+	0xA1: "PC-98 Hireso",
+	0xA2: "PC-H98",
+	0xA3: "PC-98 Note",
+	0xA4: "PC-98 Full",
+	0xFF: "Other",
+}
+
+func (c PortConnectorType) String() string {
+	return enumName(portConnectorTypeNames, c)
+}
+
+func (c PortConnectorType) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// PortType is the function of the port, such as USB or Network Port.
+type PortType uint8
+
+var portTypeNames = map[PortType]string{
+	0x00: "None",
+	0x01: "Parallel Port XT/AT Compatible",
+	0x02: "Parallel Port PS/2",
+	0x03: "Parallel Port ECP",
+	0x04: "Parallel Port EPP",
+	0x05: "Parallel Port ECP/EPP",
+	0x06: "Serial Port XT/AT Compatible",
+	0x07: "Serial Port 16450 Compatible",
+	0x08: "Serial Port 16550 Compatible",
+	0x09: "Serial Port 16550A Compatible",
+	0x0A: "SCSI Port",
+	0x0B: "MIDI Port",
+	0x0C: "Joystick Port",
+	0x0D: "Keyboard Port",
+	0x0E: "Mouse Port",
+	0x0F: "SSA SCSI",
+	0x10: "USB",
+	0x11: "Firewire (IEEE P1394)",
+	0x12: "PCMCIA Type I",
+	0x13: "PCMCIA Type II",
+	0x14: "PCMCIA Type III",
+	0x15: "Cardbus",
+	0x16: "Access Bus Port",
+	0x17: "SCSI II",
+	0x18: "SCSI Wide",
+	0x19: "PC-98",
+	0x1A: "PC-98 Hireso",
+	0x1B: "PC-H98",
+	0x1C: "Video Port",
+	0x1D: "Audio Port",
+	0x1E: "Modem Port",
+	0x1F: "Network Port",
+	0x20: "SATA",
+	0x21: "SAS",
+	0xA0: "8251 Compatible",
+	0xA1: "8251 FIFO Compatible",
+	0xFF: "Other",
+}
+
+func (p PortType) String() string {
+	return enumName(portTypeNames, p)
+}
+
+func (p PortType) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// PortConnector decodes a Type 8 (Port Connector Information) structure
+func (s Structure) PortConnector() (*PortConnector, error) {
+	if err := s.check(8, 0x09); err != nil {
+		return nil, err
+	}
+
+	pc := PortConnector{
+		InternalDesignator: s.stringAt(0x04),
+		ExternalDesignator: s.stringAt(0x06),
+	}
+	internal, _ := s.byteAt(0x05)
+	external, _ := s.byteAt(0x07)
+	portType, _ := s.byteAt(0x08)
+	pc.InternalConnectorType = PortConnectorType(internal)
+	pc.ExternalConnectorType = PortConnectorType(external)
+	pc.PortType = PortType(portType)
+
+	return &pc, nil
+}
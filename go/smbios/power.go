@@ -0,0 +1,67 @@
+package smbios
+
+// SystemPowerSupply is the decoded Type 39 structure. The characteristics word is split into its fields, the raw
+// value is kept in Characteristics.
+type SystemPowerSupply struct {
+	PowerUnitGroup        uint8  `json:"power_unit_group"`
+	Location              string `json:"location"`
+	DeviceName            string `json:"device_name"`
+	Manufacturer          string `json:"manufacturer"`
+	SerialNumber          string `json:"serial_number"`
+	AssetTag              string `json:"asset_tag"`
+	ModelPartNumber       string `json:"model_part_number"`
+	RevisionLevel         string `json:"revision_level"`
+	MaxPowerCapacity      uint16 `json:"max_power_capacity"` // watts, 0x8000 is unknown
+	Characteristics       uint16 `json:"characteristics"`
+	HotReplaceable        bool   `json:"hot_replaceable"`
+	Present               bool   `json:"present"`
+	Unplugged             bool   `json:"unplugged"`               // unplugged from the wall
+	InputVoltageSwitching uint8  `json:"input_voltage_switching"` // 3 manual, 4 auto-switch, 5 wide range, 6 not applicable
+	Status                uint8  `json:"status"`                  // 3 OK, 4 non-critical, 5 critical
+	Type                  uint8  `json:"type"`                    // 3 linear, 4 switching, 5 battery, 6 UPS, 7 converter, 8 regulator
+	InputVoltageProbe     uint16 `json:"input_voltage_probe"`     // 0xFFFF when not provided
+	CoolingDevice         uint16 `json:"cooling_device"`          // 0xFFFF when not provided
+	InputCurrentProbe     uint16 `json:"input_current_probe"`     // 0xFFFF when not provided
+}
+
+// PowerSupply decodes a Type 39 (System Power Supply) structure
+func (s Structure) PowerSupply() (*SystemPowerSupply, error) {
+	if err := s.check(39, 0x10); err != nil {
+		return nil, err
+	}
+
+	ps := SystemPowerSupply{
+		Location:        s.stringAt(0x05),
+		DeviceName:      s.stringAt(0x06),
+		Manufacturer:    s.stringAt(0x07),
+		SerialNumber:    s.stringAt(0x08),
+		AssetTag:        s.stringAt(0x09),
+		ModelPartNumber: s.stringAt(0x0A),
+		RevisionLevel:   s.stringAt(0x0B),
+	}
+	ps.PowerUnitGroup, _ = s.byteAt(0x04)
+	ps.MaxPowerCapacity, _ = s.word(0x0C)
+
+	c, _ := s.word(0x0E)
+	ps.Characteristics = c
+	ps.HotReplaceable = c&0x0001 != 0
+	ps.Present = c&0x0002 != 0
+	ps.Unplugged = c&0x0004 != 0
+	ps.InputVoltageSwitching = uint8(c>>3) & 0x0F
+	ps.Status = uint8(c>>7) & 0x07
+	ps.Type = uint8(c>>10) & 0x0F
+
+	// The probe and cooling device handles are optional, absent is the same as not provided
+	var ok bool
+	if ps.InputVoltageProbe, ok = s.word(0x10); !ok {
+		ps.InputVoltageProbe = 0xFFFF
+	}
+	if ps.CoolingDevice, ok = s.word(0x12); !ok {
+		ps.CoolingDevice = 0xFFFF
+	}
+	if ps.InputCurrentProbe, ok = s.word(0x14); !ok {
+		ps.InputCurrentProbe = 0xFFFF
+	}
+
+	return &ps, nil
+}
@@ -0,0 +1,44 @@
+package smbios
+
+// SystemPowerControls is the decoded Type 25 structure, the next scheduled power-on. A field that does not hold a
+// valid BCD value for its range is nil, it matches any value.
+type SystemPowerControls struct {
+	Month  *uint8 `json:"month"`
+	Day    *uint8 `json:"day"`
+	Hour   *uint8 `json:"hour"`
+	Minute *uint8 `json:"minute"`
+	Second *uint8 `json:"second"`
+}
+
+// PowerControls decodes a Type 25 (System Power Controls) structure
+func (s Structure) PowerControls() (*SystemPowerControls, error) {
+	if err := s.check(25, 0x09); err != nil {
+		return nil, err
+	}
+
+	pc := SystemPowerControls{
+		Month:  s.bcdAt(0x04, 1, 12),
+		Day:    s.bcdAt(0x05, 1, 31),
+		Hour:   s.bcdAt(0x06, 0, 23),
+		Minute: s.bcdAt(0x07, 0, 59),
+		Second: s.bcdAt(0x08, 0, 59),
+	}
+
+	return &pc, nil
+}
+
+// bcdAt decodes the BCD byte at offset, nil when it is not BCD or falls outside lo to hi
+func (s Structure) bcdAt(offset int, lo, hi uint8) *uint8 {
+	b, ok := s.byteAt(offset)
+	if !ok || b>>4 > 9 || b&0x0F > 9 {
+		return nil
+	}
+
+	v := int(b>>4)*10 + int(b&0x0F)
+	if v < int(lo) || v > int(hi) {
+		return nil
+	}
+
+	u := uint8(v)
+	return &u
+}
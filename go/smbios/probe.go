@@ -0,0 +1,91 @@
+package smbios
+
+// probeUnknown is the value probe readings use when the value is unknown
+const probeUnknown = 0x8000
+
+// Probe holds the layout shared by the voltage, temperature and current probes. Readings that firmware reports as
+// unknown (0x8000) are nil, the units of each reading depend on the probe type.
+type Probe struct {
+	Description  string  `json:"description"`
+	Location     uint8   `json:"location"` // 3 processor, 4 disk, 5 peripheral bay, 7 motherboard, ...
+	Status       uint8   `json:"status"`   // 3 OK, 4 non-critical, 5 critical, 6 non-recoverable
+	MaximumValue *int16  `json:"maximum_value"`
+	MinimumValue *int16  `json:"minimum_value"`
+	Resolution   *uint16 `json:"resolution"`
+	Tolerance    *int16  `json:"tolerance"` // plus or minus
+	Accuracy     *uint16 `json:"accuracy"`  // plus or minus 1/100 percent
+	OEMDefined   uint32  `json:"oem_defined"`
+	NominalValue *int16  `json:"nominal_value"`
+}
+
+// VoltageProbe is the decoded Type 26 structure. Values and tolerance are in millivolts, resolution in 1/10 millivolt.
+type VoltageProbe Probe
+
+// TemperatureProbe is the decoded Type 28 structure. Values and tolerance are in 1/10 degree C, resolution in 1/1000
+// degree C.
+type TemperatureProbe Probe
+
+// CurrentProbe is the decoded Type 29 structure. Values and tolerance are in milliamps, resolution in 1/10 milliamp.
+type CurrentProbe Probe
+
+// VoltageProbe decodes a Type 26 (Voltage Probe) structure
+func (s Structure) VoltageProbe() (*VoltageProbe, error) {
+	p, err := s.probe(26)
+	return (*VoltageProbe)(p), err
+}
+
+// TemperatureProbe decodes a Type 28 (Temperature Probe) structure
+func (s Structure) TemperatureProbe() (*TemperatureProbe, error) {
+	p, err := s.probe(28)
+	return (*TemperatureProbe)(p), err
+}
+
+// CurrentProbe decodes a Type 29 (Electrical Current Probe) structure
+func (s Structure) CurrentProbe() (*CurrentProbe, error) {
+	p, err := s.probe(29)
+	return (*CurrentProbe)(p), err
+}
+
+// probe decodes any of the probe types, the nominal value is optional
+func (s Structure) probe(typ uint8) (*Probe, error) {
+	if err := s.check(typ, 0x14); err != nil {
+		return nil, err
+	}
+
+	p := Probe{
+		Description:  s.stringAt(0x04),
+		MaximumValue: s.probeSigned(0x06),
+		MinimumValue: s.probeSigned(0x08),
+		Resolution:   s.probeUnsigned(0x0A),
+		Tolerance:    s.probeSigned(0x0C),
+		Accuracy:     s.probeUnsigned(0x0E),
+		NominalValue: s.probeSigned(0x14),
+	}
+	locStatus, _ := s.byteAt(0x05)
+	p.Location = locStatus & 0x1F
+	p.Status = locStatus >> 5
+	p.OEMDefined, _ = s.dword(0x10)
+
+	return &p, nil
+}
+
+// probeSigned returns the signed reading at offset, nil when it is unknown or not present
+func (s Structure) probeSigned(offset int) *int16 {
+	v, ok := s.word(offset)
+	if !ok || v == probeUnknown {
+		return nil
+	}
+
+	r := int16(v)
+	return &r
+}
+
+// probeUnsigned returns the unsigned reading at offset, nil when it is unknown or not present
+func (s Structure) probeUnsigned(offset int) *uint16 {
+	v, ok := s.word(offset)
+	if !ok || v == probeUnknown {
+		return nil
+	}
+
+	return &v
+}
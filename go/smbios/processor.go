@@ -0,0 +1,177 @@
+package smbios
+
+// ProcessorInformation is the decoded Type 4 structure
+type ProcessorInformation struct {
+	SocketDesignation string           `json:"socket_designation"`
+	ProcessorType     ProcessorType    `json:"processor_type"`
+	Family            ProcessorFamily  `json:"family"` // resolved from Family2 when the family byte is 0xFE
+	Manufacturer      string           `json:"manufacturer"`
+	ID                uint64           `json:"id"`
+	Version           string           `json:"version"`
+	Voltage           uint8            `json:"voltage"`
+	ExternalClock     uint16           `json:"external_clock"` // MHz
+	MaxSpeed          uint16           `json:"max_speed"`      // MHz
+	CurrentSpeed      uint16           `json:"current_speed"`  // MHz
+	Status            uint8            `json:"status"`
+	Upgrade           ProcessorUpgrade `json:"upgrade"`
+	L1CacheHandle     uint16           `json:"l1_cache_handle"` // 2.1+
+	L2CacheHandle     uint16           `json:"l2_cache_handle"` // 2.1+
+	L3CacheHandle     uint16           `json:"l3_cache_handle"` // 2.1+
+	SerialNumber      string           `json:"serial_number"`   // 2.3+
+	AssetTag          string           `json:"asset_tag"`       // 2.3+
+	PartNumber        string           `json:"part_number"`     // 2.3+
+	CoreCount         uint16           `json:"core_count"`      // 2.5+, 3.0+ for counts above 255
+	CoreEnabled       uint16           `json:"core_enabled"`    // 2.5+, 3.0+ for counts above 255
+	ThreadCount       uint16           `json:"thread_count"`    // 2.5+, 3.0+ for counts above 255
+	Characteristics   uint16           `json:"characteristics"` // 2.5+
+}
+
+// ProcessorType is the role of the processor, Central Processor for a CPU socket.
+type ProcessorType uint8
+
+var processorTypeNames = map[ProcessorType]string{
+	1: "Other",
+	2: "Unknown",
+	3: "Central Processor",
+	4: "Math Processor",
+	5: "DSP Processor",
+	6: "Video Processor",
+}
+
+func (t ProcessorType) String() string {
+	return enumName(processorTypeNames, t)
+}
+
+func (t ProcessorType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// ProcessorUpgrade is the socket or slot the processor sits in as 3.2.0 lists them, up to Socket BGA1528.
+type ProcessorUpgrade uint8
+
+var processorUpgradeNames = map[ProcessorUpgrade]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Daughter Board",
+	0x04: "ZIF Socket",
+	0x05: "Replaceable Piggy Back",
+	0x06: "None",
+	0x07: "LIF Socket",
+	0x08: "Slot 1",
+	0x09: "Slot 2",
+	0x0A: "370-pin Socket",
+	0x0B: "Slot A",
+	0x0C: "Slot M",
+	0x0D: "Socket 423",
+	0x0E: "Socket A (Socket 462)",
+	0x0F: "Socket 478",
+	0x10: "Socket 754",
+	0x11: "Socket 940",
+	0x12: "Socket 939",
+	0x13: "Socket mPGA604",
+	0x14: "Socket LGA771",
+	0x15: "Socket LGA775",
+	0x16: "Socket S1",
+	0x17: "Socket AM2",
+	0x18: "Socket F (1207)",
+	0x19: "Socket LGA1366",
+	0x1A: "Socket G34",
+	0x1B: "Socket AM3",
+	0x1C: "Socket C32",
+	0x1D: "Socket LGA1156",
+	0x1E: "Socket LGA1567",
+	0x1F: "Socket PGA988A",
+	0x20: "Socket BGA1288",
+	0x21: "Socket rPGA988B",
+	0x22: "Socket BGA1023",
+	0x23: "Socket BGA1224",
+	0x24: "Socket BGA1155",
+	0x25: "Socket LGA1356",
+	0x26: "Socket LGA2011",
+	0x27: "Socket FS1",
+	0x28: "Socket FS2",
+	0x29: "Socket FM1",
+	0x2A: "Socket FM2",
+	0x2B: "Socket LGA2011-3",
+	0x2C: "Socket LGA1356-3",
+	0x2D: "Socket LGA1150",
+	0x2E: "Socket BGA1168",
+	0x2F: "Socket BGA1234",
+	0x30: "Socket BGA1364",
+	0x31: "Socket AM4",
+	0x32: "Socket LGA1151",
+	0x33: "Socket BGA1356",
+	0x34: "Socket BGA1440",
+	0x35: "Socket BGA1515",
+	0x36: "Socket LGA3647-1",
+	0x37: "Socket SP3",
+	0x38: "Socket SP3r2",
+	0x39: "Socket LGA2066",
+	0x3A: "Socket BGA1392",
+	0x3B: "Socket BGA1510",
+	0x3C: "Socket BGA1528",
+}
+
+func (u ProcessorUpgrade) String() string {
+	return enumName(processorUpgradeNames, u)
+}
+
+func (u ProcessorUpgrade) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// Processor decodes a Type 4 (Processor Information) structure
+func (s Structure) Processor() (*ProcessorInformation, error) {
+	if err := s.check(4, 0x1A); err != nil {
+		return nil, err
+	}
+
+	family, _ := s.byteAt(0x06)
+	pi := ProcessorInformation{
+		SocketDesignation: s.stringAt(0x04),
+		Family:            ProcessorFamily(family),
+		Manufacturer:      s.stringAt(0x07),
+		Version:           s.stringAt(0x10),
+		SerialNumber:      s.stringAt(0x20),
+		AssetTag:          s.stringAt(0x21),
+		PartNumber:        s.stringAt(0x22),
+	}
+	procType, _ := s.byteAt(0x05)
+	pi.ProcessorType = ProcessorType(procType)
+	pi.ID, _ = s.qword(0x08)
+	pi.Voltage, _ = s.byteAt(0x11)
+	pi.ExternalClock, _ = s.word(0x12)
+	pi.MaxSpeed, _ = s.word(0x14)
+	pi.CurrentSpeed, _ = s.word(0x16)
+	pi.Status, _ = s.byteAt(0x18)
+	upgrade, _ := s.byteAt(0x19)
+	pi.Upgrade = ProcessorUpgrade(upgrade)
+	pi.L1CacheHandle, _ = s.word(0x1A)
+	pi.L2CacheHandle, _ = s.word(0x1C)
+	pi.L3CacheHandle, _ = s.word(0x1E)
+	coreCount, _ := s.byteAt(0x23)
+	coreEnabled, _ := s.byteAt(0x24)
+	threadCount, _ := s.byteAt(0x25)
+	pi.CoreCount = uint16(coreCount)
+	pi.CoreEnabled = uint16(coreEnabled)
+	pi.ThreadCount = uint16(threadCount)
+	pi.Characteristics, _ = s.word(0x26)
+
+	// 0xFE in the family byte means the value lives in Family2
+	if family2, ok := s.word(0x28); ok && family == 0xFE {
+		pi.Family = ProcessorFamily(family2)
+	}
+
+	// 0xFF in the legacy count bytes means the value lives in the 3.0 word fields
+	if v, ok := s.word(0x2A); ok && coreCount == 0xFF {
+		pi.CoreCount = v
+	}
+	if v, ok := s.word(0x2C); ok && coreEnabled == 0xFF {
+		pi.CoreEnabled = v
+	}
+	if v, ok := s.word(0x2E); ok && threadCount == 0xFF {
+		pi.ThreadCount = v
+	}
+
+	return &pi, nil
+}
@@ -0,0 +1,22 @@
+package smbios
+
+// OutOfBandRemoteAccess is the decoded Type 30 structure
+type OutOfBandRemoteAccess struct {
+	ManufacturerName string `json:"manufacturer_name"`
+	InboundEnabled   bool   `json:"inbound_enabled"`  // the system can be reached remotely
+	OutboundEnabled  bool   `json:"outbound_enabled"` // the system can initiate outbound connections
+}
+
+// OutOfBandRemoteAccess decodes a Type 30 (Out-of-Band Remote Access) structure
+func (s Structure) OutOfBandRemoteAccess() (*OutOfBandRemoteAccess, error) {
+	if err := s.check(30, 0x06); err != nil {
+		return nil, err
+	}
+
+	ra := OutOfBandRemoteAccess{ManufacturerName: s.stringAt(0x04)}
+	conn, _ := s.byteAt(0x05)
+	ra.InboundEnabled = conn&0x01 != 0
+	ra.OutboundEnabled = conn&0x02 != 0
+
+	return &ra, nil
+}
@@ -0,0 +1,35 @@
+package smbios
+
+// SystemReset is the decoded Type 23 structure
+type SystemReset struct {
+	Capabilities    uint8  `json:"capabilities"`
+	Enabled         bool   `json:"enabled"`           // reset enabled by the user
+	BootOption      uint8  `json:"boot_option"`       // action on a watchdog reset, 1 operating system, 2 system utilities, 3 do not reboot
+	BootOptionLimit uint8  `json:"boot_option_limit"` // action once the reset limit is reached, same values
+	WatchdogTimer   bool   `json:"watchdog_timer"`    // a watchdog timer is present
+	ResetCount      uint16 `json:"reset_count"`       // 0xFFFF is unknown
+	ResetLimit      uint16 `json:"reset_limit"`       // 0xFFFF is unknown
+	TimerInterval   uint16 `json:"timer_interval"`    // minutes, 0xFFFF is unknown
+	Timeout         uint16 `json:"timeout"`           // minutes, 0xFFFF is unknown
+}
+
+// SystemReset decodes a Type 23 (System Reset) structure
+func (s Structure) SystemReset() (*SystemReset, error) {
+	if err := s.check(23, 0x0D); err != nil {
+		return nil, err
+	}
+
+	var sr SystemReset
+	c, _ := s.byteAt(0x04)
+	sr.Capabilities = c
+	sr.Enabled = c&0x01 != 0
+	sr.BootOption = c >> 1 & 0x03
+	sr.BootOptionLimit = c >> 3 & 0x03
+	sr.WatchdogTimer = c&0x20 != 0
+	sr.ResetCount, _ = s.word(0x05)
+	sr.ResetLimit, _ = s.word(0x07)
+	sr.TimerInterval, _ = s.word(0x09)
+	sr.Timeout, _ = s.word(0x0B)
+
+	return &sr, nil
+}
@@ -0,0 +1,27 @@
+package smbios
+
+// HardwareSecurity is the decoded Type 24 structure. Each status is 0 disabled, 1 enabled, 2 not implemented or 3
+// unknown.
+type HardwareSecurity struct {
+	PowerOnPassword       uint8 `json:"power_on_password"`
+	KeyboardPassword      uint8 `json:"keyboard_password"`
+	AdministratorPassword uint8 `json:"administrator_password"`
+	FrontPanelReset       uint8 `json:"front_panel_reset"`
+}
+
+// HardwareSecurity decodes a Type 24 (Hardware Security) structure
+func (s Structure) HardwareSecurity() (*HardwareSecurity, error) {
+	if err := s.check(24, 0x05); err != nil {
+		return nil, err
+	}
+
+	settings, _ := s.byteAt(0x04)
+	hs := HardwareSecurity{
+		PowerOnPassword:       settings >> 6 & 0x03,
+		KeyboardPassword:      settings >> 4 & 0x03,
+		AdministratorPassword: settings >> 2 & 0x03,
+		FrontPanelReset:       settings & 0x03,
+	}
+
+	return &hs, nil
+}
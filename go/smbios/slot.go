@@ -0,0 +1,180 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// SystemSlot is the decoded Type 9 structure
+type SystemSlot struct {
+	Designation      string      `json:"designation"`
+	Type             SlotType    `json:"type"`
+	DataBusWidth     uint8       `json:"data_bus_width"`
+	CurrentUsage     SlotUsage   `json:"current_usage"`
+	Length           uint8       `json:"length"`
+	ID               uint16      `json:"id"`
+	Characteristics1 uint8       `json:"characteristics1"`
+	Characteristics2 uint8       `json:"characteristics2"` // 2.1+
+	SegmentGroup     uint16      `json:"segment_group"`    // 2.6+
+	BusNumber        uint8       `json:"bus_number"`       // 2.6+
+	DeviceNumber     uint8       `json:"device_number"`    // 2.6+
+	FunctionNumber   uint8       `json:"function_number"`  // 2.6+
+	BusWidth         uint8       `json:"bus_width"`        // 3.2+, electrical width in lanes
+	PeerGroups       []PeerGroup `json:"peer_groups"`      // 3.2+
+}
+
+// PeerGroup is one 5 byte entry of the Type 9 peer grouping array
+type PeerGroup struct {
+	SegmentGroup   uint16 `json:"segment_group"`
+	BusNumber      uint8  `json:"bus_number"`
+	DeviceNumber   uint8  `json:"device_number"`
+	FunctionNumber uint8  `json:"function_number"`
+	DataBusWidth   uint8  `json:"data_bus_width"`
+}
+
+// SlotType is the physical slot type, such as a PCI Express generation and width. It renders as its spec name, in JSON
+// as well.
+type SlotType uint8
+
+var slotTypeNames = map[SlotType]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "ISA",
+	0x04: "MCA",
+	0x05: "EISA",
+	0x06: "PCI",
+	0x07: "PC Card (PCMCIA)",
+	0x08: "VL-VESA",
+	0x09: "Proprietary",
+	0x0A: "Processor Card Slot",
+	0x0B: "Proprietary Memory Card Slot",
+	0x0C: "I/O Riser Card Slot",
+	0x0D: "NuBus",
+	0x0E: "PCI - 66MHz Capable",
+	0x0F: "AGP",
+	0x10: "AGP 2X",
+	0x11: "AGP 4X",
+	0x12: "PCI-X",
+	0x13: "AGP 8X",
+	0x14: "M.2 Socket 1-DP (Mechanical Key A)",
+	0x15: "M.2 Socket 1-SD (Mechanical Key E)",
+	0x16: "M.2 Socket 2 (Mechanical Key B)",
+	0x17: "M.2 Socket 3 (Mechanical Key M)",
+	0x18: "MXM Type I",
+	0x19: "MXM Type II",
+	0x1A: "MXM Type III (standard connector)",
+	0x1B: "MXM Type III (HE connector)",
+	0x1C: "MXM Type IV",
+	0x1D: "MXM 3.0 Type A",
+	0x1E: "MXM 3.0 Type B",
+	0x1F: "PCI Express Gen 2 SFF-8639",
+	0x20: "PCI Express Gen 3 SFF-8639",
+	0x21: "PCI Express Mini 52-pin with bottom-side keep-outs",
+	0x22: "PCI Express Mini 52-pin without bottom-side keep-outs",
+	0x23: "PCI Express Mini 76-pin",
+	0xA0: "PC-98/C20",
+	0xA1: "PC-98/C24",
+	0xA2: "PC-98/E",
+	0xA3: "PC-98/Local Bus",
+	0xA4: "PC-98/Card",
+	0xA5: "PCI Express",
+	0xA6: "PCI Express x1",
+	0xA7: "PCI Express x2",
+	0xA8: "PCI Express x4",
+	0xA9: "PCI Express x8",
+	0xAA: "PCI Express x16",
+	0xAB: "PCI Express Gen 2",
+	0xAC: "PCI Express Gen 2 x1",
+	0xAD: "PCI Express Gen 2 x2",
+	0xAE: "PCI Express Gen 2 x4",
+	0xAF: "PCI Express Gen 2 x8",
+	0xB0: "PCI Express Gen 2 x16",
+	0xB1: "PCI Express Gen 3",
+	0xB2: "PCI Express Gen 3 x1",
+	0xB3: "PCI Express Gen 3 x2",
+	0xB4: "PCI Express Gen 3 x4",
+	0xB5: "PCI Express Gen 3 x8",
+	0xB6: "PCI Express Gen 3 x16",
+	0xB8: "PCI Express Gen 4",
+	0xB9: "PCI Express Gen 4 x1",
+	0xBA: "PCI Express Gen 4 x2",
+	0xBB: "PCI Express Gen 4 x4",
+	0xBC: "PCI Express Gen 4 x8",
+	0xBD: "PCI Express Gen 4 x16",
+}
+
+func (t SlotType) String() string {
+	return enumName(slotTypeNames, t)
+}
+
+func (t SlotType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// SlotUsage is whether the slot is populated. It renders as its spec name, in JSON as well.
+type SlotUsage uint8
+
+var slotUsageNames = map[SlotUsage]string{
+	1: "Other",
+	2: "Unknown",
+	3: "Available",
+	4: "In use",
+	5: "Unavailable", // 3.2+
+}
+
+func (u SlotUsage) String() string {
+	return enumName(slotUsageNames, u)
+}
+
+func (u SlotUsage) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// SystemSlot decodes a Type 9 (System Slots) structure
+func (s Structure) SystemSlot() (*SystemSlot, error) {
+	if err := s.check(9, 0x0C); err != nil {
+		return nil, err
+	}
+
+	ss := SystemSlot{
+		Designation: s.stringAt(0x04),
+		PeerGroups:  []PeerGroup{},
+	}
+	typ, _ := s.byteAt(0x05)
+	ss.Type = SlotType(typ)
+	ss.DataBusWidth, _ = s.byteAt(0x06)
+	usage, _ := s.byteAt(0x07)
+	ss.CurrentUsage = SlotUsage(usage)
+	ss.Length, _ = s.byteAt(0x08)
+	ss.ID, _ = s.word(0x09)
+	ss.Characteristics1, _ = s.byteAt(0x0B)
+	ss.Characteristics2, _ = s.byteAt(0x0C)
+	ss.SegmentGroup, _ = s.word(0x0D)
+	ss.BusNumber, _ = s.byteAt(0x0F)
+	devFn, _ := s.byteAt(0x10)
+	ss.DeviceNumber = devFn >> 3
+	ss.FunctionNumber = devFn & 0x07
+	ss.BusWidth, _ = s.byteAt(0x11)
+
+	count, ok := s.byteAt(0x12)
+	if !ok {
+		return &ss, nil
+	}
+
+	// n peer groups of 5 bytes each follow the count
+	for i := 0; i < int(count); i++ {
+		g, ok := s.field(0x13+i*5, 5)
+		if !ok {
+			return nil, errors.New("type 9 peer groups exceed structure length")
+		}
+		ss.PeerGroups = append(ss.PeerGroups, PeerGroup{
+			SegmentGroup:   binary.LittleEndian.Uint16(g[0:2]),
+			BusNumber:      g[2],
+			DeviceNumber:   g[3] >> 3,
+			FunctionNumber: g[3] & 0x07,
+			DataBusWidth:   g[4],
+		})
+	}
+
+	return &ss, nil
+}
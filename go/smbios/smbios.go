@@ -0,0 +1,158 @@
+/*
+Package smbios reads SMBIOS information based on version 3.2.0 from the DMTF published on 04/26/2018.
+Link: https://www.dmtf.org/sites/default/files/standards/documents/DSP0134_3.2.0.pdf
+*/
+package smbios
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// SpecVersion is the latest version of DSP0134 the decoders follow
+const SpecVersion = "3.2.0"
+
+const headerLen = 4
+
+type Header struct {
+	Type   uint8  `json:"type"`
+	Length uint8  `json:"length"`
+	Handle uint16 `json:"handle"`
+}
+
+type Structure struct {
+	Formatterd []byte   `json:"formatted"` // encoded as base64 in JSON
+	Strings    []string `json:"strings"`
+	Header     Header   `json:"header"`
+}
+
+type SmTable struct {
+	EntryPoint *EntryPoint `json:"entry_point"`
+	Structures []Structure `json:"structures"`
+
+	handlesOnce sync.Once
+	handles     map[uint16]int // index into Structures, built once on the first lookup
+}
+
+// Parse reads the entry point and the DMI structure table and returns the populated table. An entry point checksum
+// mismatch does not stop parsing, the table is returned along with the *ChecksumError. A table error returns the
+// structures parsed before it, see ErrTruncated. A table that disagrees with the entry point is returned with the
+// error from CheckAgainst.
+func Parse(entryPoint io.Reader, dmi io.Reader) (*SmTable, error) {
+	return ParseContext(context.Background(), entryPoint, dmi)
+}
+
+// ParseContext is Parse with cancellation. ctx is checked before the entry point and after each structure, once it is
+// done parsing stops and the structures read so far are returned with ctx.Err(). A read that blocks is not
+// interrupted, the check happens when it returns.
+func ParseContext(ctx context.Context, entryPoint io.Reader, dmi io.Reader) (*SmTable, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ep, chkErr := parseSmbEntryPoint(entryPoint)
+	var ce *ChecksumError
+	if chkErr != nil && !errors.As(chkErr, &ce) {
+		return nil, chkErr
+	}
+
+	t, err := parseDmiTable(ctx, dmi)
+	t.EntryPoint = ep
+	if err == nil {
+		err = t.CheckAgainst(ep)
+	}
+
+	return t, errors.Join(chkErr, err)
+}
+
+// check verifies the structure is of type typ and that the formatted area is at least length bytes long. The length
+// is given as the spec offset (header included) of the end of the last required field.
+func (s Structure) check(typ uint8, length int) error {
+	if s.Header.Type != typ {
+		return fmt.Errorf("structure type %d, expected type %d", s.Header.Type, typ)
+	}
+
+	if int(s.Header.Length) < length || len(s.Formatterd) < length-headerLen {
+		return fmt.Errorf("type %d structure too short: %d bytes, need %d", typ, s.Header.Length, length)
+	}
+
+	return nil
+}
+
+// badIndex is returned by String when a reference points past the end of the string table
+const badIndex = "<BAD INDEX>"
+
+// String resolves a 1 based string reference against the string table. A reference of 0 means no string and returns
+// an empty string, a reference past the end of the table returns "<BAD INDEX>".
+func (s Structure) String(ref uint8) string {
+	if ref == 0 {
+		return ""
+	}
+
+	if int(ref) > len(s.Strings) {
+		return badIndex
+	}
+
+	return s.Strings[ref-1]
+}
+
+// field returns n bytes starting at the spec offset. The offset includes the 4 byte header so the values from the
+// spec tables can be used directly. ok is false when the field lies past the end of the structure.
+func (s Structure) field(offset int, n int) ([]byte, bool) {
+	i := offset - headerLen
+	if i < 0 || n < 0 || i+n > len(s.Formatterd) || offset+n > int(s.Header.Length) {
+		return nil, false
+	}
+
+	return s.Formatterd[i : i+n], true
+}
+
+// byteAt reads the byte at the spec offset. Fields past the end of the structure read as 0 with ok set to false.
+func (s Structure) byteAt(offset int) (uint8, bool) {
+	b, ok := s.field(offset, 1)
+	if !ok {
+		return 0, false
+	}
+
+	return b[0], true
+}
+
+// word reads the little endian uint16 at the spec offset
+func (s Structure) word(offset int) (uint16, bool) {
+	b, ok := s.field(offset, 2)
+	if !ok {
+		return 0, false
+	}
+
+	return binary.LittleEndian.Uint16(b), true
+}
+
+// dword reads the little endian uint32 at the spec offset
+func (s Structure) dword(offset int) (uint32, bool) {
+	b, ok := s.field(offset, 4)
+	if !ok {
+		return 0, false
+	}
+
+	return binary.LittleEndian.Uint32(b), true
+}
+
+// qword reads the little endian uint64 at the spec offset
+func (s Structure) qword(offset int) (uint64, bool) {
+	b, ok := s.field(offset, 8)
+	if !ok {
+		return 0, false
+	}
+
+	return binary.LittleEndian.Uint64(b), true
+}
+
+// stringAt resolves the string reference stored at the spec offset
+func (s Structure) stringAt(offset int) string {
+	ref, _ := s.byteAt(offset)
+	return s.String(ref)
+}
@@ -0,0 +1,957 @@
+package smbios
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+var fixtures = []struct {
+	name  string
+	entry EntryPoint
+	types []uint8
+}{
+	{
+		name: "synthetic_sm2",
+		entry: EntryPoint{
+			Anchor:                "_SM_",
+			IntermediateAnchor:    "_DMI_",
+			Checksum:              0xF6,
+			Length:                0x1F,
+			Major:                 2,
+			Minor:                 8,
+			MaxStructureSize:      131,
+			IntermediateChecksum:  0xE2,
+			StructureTableLength:  1190,
+			StructureTableAddress: 0x000E9000,
+			NumberStructures:      22,
+			BCDRevision:           0x28,
+		},
+		types: []uint8{0, 1, 2, 3, 4, 4, 7, 7, 7, 7, 7, 7, 9, 9, 16, 17, 17, 17, 17, 19, 32, 127},
+	},
+	{
+		name: "synthetic_sm3",
+		entry: EntryPoint{
+			Anchor:                "_SM3_",
+			Checksum:              0xC6,
+			Length:                0x18,
+			Major:                 3,
+			Minor:                 2,
+			EntryPointRevision:    1,
+			StructureTableMaxSize: 1161,
+			StructureTableAddress: 0x6F0F8000,
+		},
+		types: []uint8{0, 1, 2, 3, 4, 7, 7, 7, 9, 16, 17, 17, 17, 17, 32, 127},
+	},
+}
+
+func TestParseSmbEntryPoint(t *testing.T) {
+	for _, tt := range fixtures {
+		t.Run(tt.name, func(t *testing.T) {
+			ep, err := parseSmbEntryPoint(bytes.NewReader(readFixture(t, tt.name+"_entry.bin")))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(*ep, tt.entry) {
+				t.Errorf("entry point\ngot  %+v\nwant %+v", *ep, tt.entry)
+			}
+		})
+	}
+}
+
+func TestParseDmiTable(t *testing.T) {
+	for _, tt := range fixtures {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl, err := parseDmiTable(context.Background(), bytes.NewReader(readFixture(t, tt.name+"_dmi.bin")))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			types := make([]uint8, len(tbl.Structures))
+			for i, s := range tbl.Structures {
+				types[i] = s.Header.Type
+				if len(s.Formatterd) != int(s.Header.Length)-headerLen {
+					t.Errorf("structure %d: formatted area is %d bytes, header length %d", i, len(s.Formatterd), s.Header.Length)
+				}
+			}
+
+			if !reflect.DeepEqual(types, tt.types) {
+				t.Errorf("structure types\ngot  %v\nwant %v", types, tt.types)
+			}
+		})
+	}
+}
+
+func TestParseZeroStrings(t *testing.T) {
+	// Two structures without strings around one with a string, then end-of-table with trailing padding
+	raw := []byte{
+		0x80, 0x05, 0x01, 0x00, 0xAA, 0x00, 0x00,
+		0x81, 0x04, 0x02, 0x00, 0x00, 0x00,
+		0x82, 0x05, 0x03, 0x00, 0x01, 'x', 0x00, 0x00,
+		0x83, 0x04, 0x04, 0x00, 0x00, 0x00,
+		endOfTable, 0x04, 0x05, 0x00, 0x00, 0x00,
+		0xFF, 0xFF,
+	}
+
+	tbl, err := parseDmiTable(context.Background(), bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		handle  uint16
+		strings []string
+	}{
+		{1, []string{}},
+		{2, []string{}},
+		{3, []string{"x"}},
+		{4, []string{}},
+		{5, []string{}},
+	}
+	if len(tbl.Structures) != len(want) {
+		t.Fatalf("got %d structures, want %d", len(tbl.Structures), len(want))
+	}
+	for i, w := range want {
+		s := tbl.Structures[i]
+		if s.Header.Handle != w.handle || !reflect.DeepEqual(s.Strings, w.strings) {
+			t.Errorf("structure %d: handle 0x%04X strings %q, want handle 0x%04X strings %q", i, s.Header.Handle,
+				s.Strings, w.handle, w.strings)
+		}
+	}
+}
+
+func TestParseBadChecksum(t *testing.T) {
+	b := readFixture(t, "synthetic_sm2_entry.bin")
+	b[4]++
+
+	ep, err := parseSmbEntryPoint(bytes.NewReader(b))
+	if err == nil {
+		t.Fatal("expected a checksum error")
+	}
+	if ep == nil {
+		t.Fatal("entry point should still be returned on a checksum error")
+	}
+
+	tbl, err := Parse(bytes.NewReader(b), bytes.NewReader(readFixture(t, "synthetic_sm2_dmi.bin")))
+	var chkErr *ChecksumError
+	if !errors.As(err, &chkErr) || chkErr.Name != "entry point" {
+		t.Fatalf("got %v, want an entry point *ChecksumError", err)
+	}
+	if tbl == nil || len(tbl.Structures) != len(fixtures[0].types) {
+		t.Fatal("table should still be parsed on a checksum error")
+	}
+}
+
+func TestParseBadIntermediateChecksum(t *testing.T) {
+	// Moving one from the formatted area to the BCD revision keeps the whole entry point summing to 0 but breaks the
+	// _DMI_ area
+	b := readFixture(t, "synthetic_sm2_entry.bin")
+	b[0x0B]--
+	b[0x1E]++
+
+	ep, err := parseSmbEntryPoint(bytes.NewReader(b))
+	var chkErr *ChecksumError
+	if !errors.As(err, &chkErr) || chkErr.Name != "intermediate" {
+		t.Fatalf("got %v, want an intermediate *ChecksumError", err)
+	}
+	if strings.Contains(err.Error(), "entry point checksum") {
+		t.Errorf("entry point checksum should still pass: %v", err)
+	}
+	if ep == nil || ep.BCDRevision != 0x29 {
+		t.Error("entry point should still be returned on an intermediate checksum error")
+	}
+}
+
+func TestCheckAgainst(t *testing.T) {
+	tbl, err := parseDmiTable(context.Background(), bytes.NewReader(readFixture(t, "synthetic_sm2_dmi.bin")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		edit func(ep *EntryPoint)
+		want []string
+	}{
+		{"matching", func(ep *EntryPoint) {}, nil},
+		{"smaller maximum", func(ep *EntryPoint) { ep.MaxStructureSize = 100 }, []string{"more than the 100 byte maximum"}},
+		{"fewer structures", func(ep *EntryPoint) { ep.NumberStructures = 21 },
+			[]string{"lists 21 structures, table has 22"}},
+		{"both", func(ep *EntryPoint) { ep.MaxStructureSize, ep.NumberStructures = 100, 30 },
+			[]string{"more than the 100 byte maximum", "lists 30 structures"}},
+		{"3.0 entry point", func(ep *EntryPoint) { ep.MaxStructureSize, ep.NumberStructures = 0, 0 }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := fixtures[0].entry
+			tt.edit(&ep)
+
+			err := tbl.CheckAgainst(&ep)
+			if tt.want == nil && err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tt.want != nil && err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// Type 1 with the manufacturer, product, version and serial number strings set, UUID bytes all 0xFF
+	system := func(edit func(f []byte)) Structure {
+		f := make([]byte, 0x1B-headerLen)
+		copy(f, []byte{1, 2, 0, 0})
+		for i := 0x08; i < 0x18; i++ {
+			f[i-headerLen] = 0xFF
+		}
+		edit(f)
+		return Structure{Header: Header{Type: 1, Length: 0x1B, Handle: 0x0100}, Formatterd: f, Strings: []string{"A", "B"}}
+	}
+
+	tests := []struct {
+		name string
+		s    Structure
+		want []string
+	}{
+		{"0xFF at non-string offsets", system(func(f []byte) {}), nil},
+		{"reference past the strings", system(func(f []byte) { f[0x07-headerLen] = 3 }),
+			[]string{"offset 0x07 refers to string 3, 2 present"}},
+		{"several bad references", system(func(f []byte) { f[0x04-headerLen], f[0x19-headerLen] = 5, 9 }),
+			[]string{"offset 0x04 refers to string 5", "offset 0x19 refers to string 9"}},
+		{"short formatted area", Structure{Header: Header{Type: 1, Length: 0x1B}, Formatterd: make([]byte, 4)},
+			[]string{"formatted area is 4 bytes, header length 27 needs 23"}},
+		{"length below the header", Structure{Header: Header{Type: 1, Length: 2}},
+			[]string{"shorter than the 4 byte header"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.s.Validate()
+			if tt.want == nil && err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tt.want != nil && err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+
+	// The fixtures are well formed
+	for _, tt := range fixtures {
+		tbl, err := parseDmiTable(context.Background(), bytes.NewReader(readFixture(t, tt.name+"_dmi.bin")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range tbl.Structures {
+			if err := s.Validate(); err != nil {
+				t.Errorf("%s: structure 0x%04X: %v", tt.name, s.Header.Handle, err)
+			}
+		}
+	}
+}
+
+func TestDecodeFixtures(t *testing.T) {
+	tbl, err := Parse(bytes.NewReader(readFixture(t, "synthetic_sm3_entry.bin")), bytes.NewReader(readFixture(t, "synthetic_sm3_dmi.bin")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bi, err := tbl.ByType(0)[0].BIOS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bi.Vendor != "American Megatrends Inc." || bi.ReleaseDate != "10/15/2021" || bi.ROMSize != 32<<20 ||
+		bi.BIOSMajor != 3 || bi.BIOSMinor != 4 || bi.FirmwareMajor != 0xFF {
+		t.Errorf("BIOS information: %+v", bi)
+	}
+
+	si, err := tbl.ByType(1)[0].System()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if si.ProductName != "SYS-1029P-WTR" || si.UUID != "00000000-0000-0000-0000-AC1F6B4A5C3E" {
+		t.Errorf("system information: %+v", si)
+	}
+
+	bb, err := tbl.ByType(2)[0].Baseboard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bb.Product != "X11DDW-L" || bb.FeatureFlags != 0x09 || bb.ChassisHandle != 0x0003 ||
+		bb.BoardType.String() != "Motherboard" || len(bb.ObjectHandles) != 0 {
+		t.Errorf("baseboard information: %+v", bb)
+	}
+
+	ch, err := tbl.ByType(3)[0].Chassis()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantElements := []ContainedElement{{Type: 0x91, Minimum: 1, Maximum: 2}, {Type: 0x11, Minimum: 1, Maximum: 1}}
+	if ch.Type.String() != "Rack Mount Chassis" || ch.Lock || ch.Height != 1 || ch.NumberPowerCords != 1 ||
+		!reflect.DeepEqual(ch.ContainedElements, wantElements) || ch.SKUNumber != "Default string" {
+		t.Errorf("chassis information: %+v", ch)
+	}
+
+	pi, err := tbl.ByType(4)[0].Processor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pi.Family != 0xB3 || pi.Upgrade.String() != "Socket LGA3647-1" || pi.CoreCount != 16 || pi.ThreadCount != 32 {
+		t.Errorf("processor family %#x, upgrade %s, cores %d, threads %d", uint16(pi.Family), pi.Upgrade, pi.CoreCount,
+			pi.ThreadCount)
+	}
+
+	wantCaches := []struct {
+		level     uint8
+		size      uint64
+		cacheType string
+	}{
+		{1, 1 << 20, "Unified"},
+		{2, 16 << 20, "Data"},
+		{3, 22 << 20, "Unified"},
+	}
+	for i, s := range tbl.ByType(7) {
+		ci, err := s.Cache()
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := wantCaches[i]
+		if ci.Level != w.level || ci.InstalledSize != w.size || ci.SystemCacheType.String() != w.cacheType ||
+			ci.OperationalMode.String() != "Write Back" || ci.ErrorCorrectionType.String() != "Multi-bit ECC" {
+			t.Errorf("%s: %+v", ci.SocketDesignation, ci)
+		}
+	}
+
+	ss, err := tbl.ByType(9)[0].SystemSlot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPeers := []PeerGroup{{BusNumber: 0x17, DeviceNumber: 1, DataBusWidth: 8}}
+	if ss.Designation != "CPU1 SLOT1 PCI-E 4.0 X16" || ss.Type.String() != "PCI Express Gen 3 x16" ||
+		ss.CurrentUsage.String() != "In use" || ss.BusNumber != 0x17 || ss.BusWidth != 16 ||
+		!reflect.DeepEqual(ss.PeerGroups, wantPeers) {
+		t.Errorf("system slot: %+v", ss)
+	}
+
+	ma, err := tbl.ByType(16)[0].PhysicalMemoryArray()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ma.Use.String() != "System Memory" || ma.ErrorCorrection.String() != "Multi-bit ECC" ||
+		ma.MaximumCapacity != 1<<40 || ma.ErrorInfoHandle != 0xFFFE || ma.NumberDevices != 4 {
+		t.Errorf("physical memory array: %+v", ma)
+	}
+
+	wantSizes := []uint64{64 << 30, 16 << 30, 0, 0}
+	for i, s := range tbl.ByType(17) {
+		md, err := s.MemoryDevice()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if md.Size != wantSizes[i] {
+			t.Errorf("%s: size %d, want %d", md.DeviceLocator, md.Size, wantSizes[i])
+		}
+	}
+
+	sb, err := tbl.ByType(32)[0].SystemBoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sb.Status != 0 || sb.StatusName != "No errors detected" || len(sb.AdditionalData) != 0 {
+		t.Errorf("system boot information: %+v", sb)
+	}
+}
+
+func TestEnumNames(t *testing.T) {
+	tests := []struct {
+		v interface {
+			String() string
+			MarshalText() ([]byte, error)
+		}
+		want string
+	}{
+		{WakeUpType(6), "Power Switch"},
+		{WakeUpType(0x20), "Unknown (0x20)"},
+		{MemoryType(0x1A), "DDR4"},
+		{MemoryType(0xF0), "Unknown (0xF0)"},
+		{FormFactor(0x09), "DIMM"},
+		{FormFactor(0xF0), "Unknown (0xF0)"},
+		{ProcessorFamily(0xB3), "Xeon"},
+		{ProcessorFamily(0x1234), "Unknown (0x1234)"},
+		{SlotType(0x06), "PCI"},
+		{SlotType(0xF0), "Unknown (0xF0)"},
+		{SlotUsage(3), "Available"},
+		{SlotUsage(0x20), "Unknown (0x20)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.want {
+			t.Errorf("%T: String %q, want %q", tt.v, got, tt.want)
+		}
+		if b, _ := tt.v.MarshalText(); string(b) != tt.want {
+			t.Errorf("%T: MarshalText %q, want %q", tt.v, b, tt.want)
+		}
+	}
+}
+
+func TestPowerControls(t *testing.T) {
+	// December 31st at 23:59:45, then out of range and non-BCD values that must match any value
+	tests := []struct {
+		name      string
+		formatted []byte
+		want      []int // -1 for nil
+	}{
+		{"valid", []byte{0x12, 0x31, 0x23, 0x59, 0x45}, []int{12, 31, 23, 59, 45}},
+		{"out of range", []byte{0x13, 0x00, 0x24, 0x60, 0x00}, []int{-1, -1, -1, -1, 0}},
+		{"not BCD", []byte{0x1A, 0xA1, 0xFF, 0x09, 0x5F}, []int{-1, -1, -1, 9, -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Structure{Header: Header{Type: 25, Length: 0x09}, Formatterd: tt.formatted}
+			pc, err := s.PowerControls()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []int{}
+			for _, v := range []*uint8{pc.Month, pc.Day, pc.Hour, pc.Minute, pc.Second} {
+				if v == nil {
+					got = append(got, -1)
+				} else {
+					got = append(got, int(*v))
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("month, day, hour, minute, second\ngot  %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteToRoundTrip(t *testing.T) {
+	for _, tt := range fixtures {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := readFixture(t, tt.name+"_dmi.bin")
+			tbl, err := parseDmiTable(context.Background(), bytes.NewReader(raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			n, err := tbl.WriteTo(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != int64(buf.Len()) {
+				t.Errorf("WriteTo returned %d, wrote %d bytes", n, buf.Len())
+			}
+			if !bytes.Equal(buf.Bytes(), raw) {
+				t.Error("re-encoded table differs from the fixture")
+			}
+
+			again, err := parseDmiTable(context.Background(), &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(again.Structures, tbl.Structures) {
+				t.Error("round trip table differs")
+			}
+		})
+	}
+}
+
+func TestWriteToEndHandle(t *testing.T) {
+	tests := []struct {
+		name    string
+		handles []uint16
+		want    uint16
+	}{
+		{"after the highest", []uint16{0x0001, 0x0002}, 0x0003},
+		{"highest is 0xFFFF", []uint16{0x0000, 0xFFFF}, 0x0001},
+		{"highest is 0xFFFD", []uint16{0xFFFD}, 0x0000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := &SmTable{}
+			for _, h := range tt.handles {
+				tbl.Structures = append(tbl.Structures, Structure{Header: Header{Type: 0x80, Length: headerLen, Handle: h},
+					Formatterd: []byte{}, Strings: []string{}})
+			}
+
+			var buf bytes.Buffer
+			if _, err := tbl.WriteTo(&buf); err != nil {
+				t.Fatal(err)
+			}
+			again, err := parseDmiTable(context.Background(), &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(again.Structures) != len(tt.handles)+1 {
+				t.Fatalf("got %d structures, want %d", len(again.Structures), len(tt.handles)+1)
+			}
+			end := again.Structures[len(tt.handles)].Header
+			if end.Type != endOfTable || end.Handle != tt.want {
+				t.Errorf("end-of-table type %d handle 0x%04X, want type %d handle 0x%04X", end.Type, end.Handle,
+					endOfTable, tt.want)
+			}
+		})
+	}
+}
+
+func TestByHandleConcurrent(t *testing.T) {
+	tbl, err := parseDmiTable(context.Background(), bytes.NewReader(readFixture(t, "synthetic_sm2_dmi.bin")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for _, s := range tbl.Structures {
+		wg.Add(1)
+		go func(h uint16) {
+			defer wg.Done()
+			if got, ok := tbl.ByHandle(h); !ok || got.Header.Handle != h {
+				t.Errorf("handle 0x%04X not found", h)
+			}
+			if _, ok := tbl.ByHandle(0xFFFE); ok {
+				t.Error("absent handle 0xFFFE found")
+			}
+		}(s.Header.Handle)
+	}
+	wg.Wait()
+
+	tbl.SortByTypeHandle()
+	seen := map[uint16]bool{}
+	for i, s := range tbl.Structures {
+		if seen[s.Header.Handle] {
+			continue
+		}
+		seen[s.Header.Handle] = true
+		if got, _ := tbl.ByHandle(s.Header.Handle); got != &tbl.Structures[i] {
+			t.Errorf("handle 0x%04X not at index %d after sorting", s.Header.Handle, i)
+		}
+	}
+}
+
+// writeMemImage saves a fake physical memory image holding the synthetic_sm2 table at the address its entry point
+// gives, and the entry point on a 16 byte boundary in the BIOS area when anchored is set
+func writeMemImage(t *testing.T, anchored bool) string {
+	t.Helper()
+
+	entry, dmi := readFixture(t, "synthetic_sm2_entry.bin"), readFixture(t, "synthetic_sm2_dmi.bin")
+	img := make([]byte, memScanEnd)
+	copy(img[fixtures[0].entry.StructureTableAddress:], dmi)
+	if anchored {
+		copy(img[memScanStart+0x120:], entry)
+	}
+
+	path := filepath.Join(t.TempDir(), "mem")
+	if err := os.WriteFile(path, img, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestMemSource(t *testing.T) {
+	dmi := readFixture(t, "synthetic_sm2_dmi.bin")
+	tests := []struct {
+		name     string
+		anchored bool
+		entry    string
+	}{
+		{"scan", true, ""},
+		{"entry point file", false, filepath.Join("testdata", "synthetic_sm2_entry.bin")},
+		{"missing entry point file", true, filepath.Join("testdata", "missing")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeMemImage(t, tt.anchored)
+			src := &MemSource{Path: path, EntryPointPath: tt.entry}
+
+			tbl, err := ParseSource(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tbl.Structures) != len(fixtures[0].types) {
+				t.Errorf("parsed %d structures, want %d", len(tbl.Structures), len(fixtures[0].types))
+			}
+
+			// The image is only read once, the table is served from that read
+			if err := os.Remove(path); err != nil {
+				t.Fatal(err)
+			}
+			raw, err := src.Table()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(raw, dmi) {
+				t.Error("table differs from the fixture")
+			}
+		})
+	}
+
+	_, err := ParseSource(&MemSource{Path: writeMemImage(t, false)})
+	if !errors.Is(err, ErrNoSMBIOS) {
+		t.Errorf("unanchored image without an entry point file: got %v, want ErrNoSMBIOS", err)
+	}
+}
+
+// rawStructure encodes a structure with the given formatted area, which starts after the header, and strings
+func rawStructure(typ uint8, handle uint16, formatted []byte, strs ...string) []byte {
+	b := append([]byte{typ, uint8(headerLen + len(formatted)), uint8(handle), uint8(handle >> 8)}, formatted...)
+	for _, str := range strs {
+		b = append(append(b, str...), 0)
+	}
+	if len(strs) == 0 {
+		b = append(b, 0)
+	}
+
+	return append(b, 0)
+}
+
+// memoryDevice encodes a minimal Type 17 with the given error information handle
+func memoryDevice(handle, errHandle uint16) []byte {
+	f := make([]byte, 0x15-headerLen)
+	binary.LittleEndian.PutUint16(f[0x06-headerLen:], errHandle)
+
+	return rawStructure(17, handle, f)
+}
+
+func TestInventoryTotals(t *testing.T) {
+	tests := []struct {
+		name                    string
+		memory                  uint64
+		sockets, cores, threads int
+	}{
+		{"synthetic_sm2", 32 << 30, 2, 24, 48},
+		// P1-DIMMA1 is 64 GiB through the extended size field
+		{"synthetic_sm3", 80 << 30, 1, 16, 32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl, err := Parse(bytes.NewReader(readFixture(t, tt.name+"_entry.bin")),
+				bytes.NewReader(readFixture(t, tt.name+"_dmi.bin")))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, err := tbl.TotalMemoryBytes(); err != nil || got != tt.memory {
+				t.Errorf("TotalMemoryBytes: got %d, %v, want %d", got, err, tt.memory)
+			}
+			sockets, cores, threads, err := tbl.CPUTotals()
+			if err != nil || sockets != tt.sockets || cores != tt.cores || threads != tt.threads {
+				t.Errorf("CPUTotals: got %d sockets, %d cores, %d threads, %v", sockets, cores, threads, err)
+			}
+
+			// Clearing the populated status bit of the first socket, and marking the first DIMM's size unknown, drops
+			// both from the totals
+			for i, s := range tbl.Structures {
+				f := append([]byte{}, s.Formatterd...)
+				switch s.Header.Type {
+				case 4:
+					f[0x18-headerLen] &^= 0x40
+				case 17:
+					binary.LittleEndian.PutUint16(f[0x0C-headerLen:], 0xFFFF)
+				}
+				tbl.Structures[i].Formatterd = f
+			}
+			sockets, _, _, _ = tbl.CPUTotals()
+			if sockets != 0 {
+				t.Errorf("CPUTotals counted %d unpopulated sockets", sockets)
+			}
+			if got, _ := tbl.TotalMemoryBytes(); got != 0 {
+				t.Errorf("TotalMemoryBytes counted %d bytes of unknown size", got)
+			}
+		})
+	}
+}
+
+func TestMemoryTopology(t *testing.T) {
+	// Interleave DIMMs A1 and A2 across the sm2 array range, A2 first
+	mapping := func(handle, device uint16, position uint8) []byte {
+		f := make([]byte, 0x13-headerLen)
+		binary.LittleEndian.PutUint32(f[0x08-headerLen:], 32<<20-1)
+		binary.LittleEndian.PutUint16(f[0x0C-headerLen:], device)
+		binary.LittleEndian.PutUint16(f[0x0E-headerLen:], 0x1300)
+		f[0x10-headerLen], f[0x11-headerLen], f[0x12-headerLen] = 0xFF, position, 2
+		return rawStructure(20, handle, f)
+	}
+	dmi := readFixture(t, "synthetic_sm2_dmi.bin")
+	raw := append(append([]byte{}, dmi[:len(dmi)-6]...), mapping(0x1400, 0x1100, 2)...)
+	raw = append(append(raw, mapping(0x1401, 0x1101, 1)...), dmi[len(dmi)-6:]...)
+	tbl, err := ParseTableBytes(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mt, err := tbl.MemoryTopology()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mt.Arrays) != 1 || len(mt.Unassigned) != 0 {
+		t.Fatalf("got %d arrays and %d unassigned devices, want 1 and 0", len(mt.Arrays), len(mt.Unassigned))
+	}
+	a := mt.Arrays[0]
+	if a.Handle != 0x1000 || len(a.Devices) != 4 || len(a.Ranges) != 1 {
+		t.Fatalf("array 0x%04X with %d devices and %d ranges", a.Handle, len(a.Devices), len(a.Ranges))
+	}
+	if r := a.Ranges[0]; r.Range.EndingAddress != 32<<30-1 || !reflect.DeepEqual(r.Devices, []uint16{0x1101, 0x1100}) {
+		t.Errorf("range ends at %#x, backed by %#04x", r.Range.EndingAddress, r.Devices)
+	}
+	if m := a.Devices[0].Mappings; len(m) != 1 || m[0].InterleavePosition != 2 || m[0].EndingAddress != 32<<30-1 {
+		t.Errorf("A1 mappings %+v", m)
+	}
+	if m := a.Devices[2].Mappings; len(m) != 0 {
+		t.Errorf("empty slot B1 has mappings %+v", m)
+	}
+}
+
+func TestMemoryErrorFor(t *testing.T) {
+	// Type 18, single-bit error with an unknown array address and resolution
+	err32 := []byte{6, 3, 3, 0x78, 0x56, 0x34, 0x12}
+	err32 = binary.LittleEndian.AppendUint32(err32, 0x80000000)
+	err32 = binary.LittleEndian.AppendUint32(err32, 0x00001000)
+	err32 = binary.LittleEndian.AppendUint32(err32, 0x80000000)
+
+	// Type 33, the same with 64-bit addresses and a known resolution
+	err64 := []byte{6, 3, 3, 0, 0, 0, 0}
+	err64 = binary.LittleEndian.AppendUint64(err64, 0x8000000000000000)
+	err64 = binary.LittleEndian.AppendUint64(err64, 0x0000000100000000)
+	err64 = binary.LittleEndian.AppendUint32(err64, 64)
+
+	var raw []byte
+	raw = append(raw, rawStructure(18, 0x1800, err32)...)
+	raw = append(raw, rawStructure(33, 0x3300, err64)...)
+	raw = append(raw, memoryDevice(0x1100, 0x1800)...)
+	raw = append(raw, memoryDevice(0x1101, 0x3300)...)
+	raw = append(raw, memoryDevice(0x1102, 0xFFFF)...)
+	raw = append(raw, memoryDevice(0x1103, 0xFFFE)...)
+	raw = append(raw, memoryDevice(0x1104, 0x1101)...)
+	tbl, err := ParseTableBytes(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	device := func(h uint16) *MemoryDevice {
+		s, _ := tbl.ByHandle(h)
+		md, err := s.MemoryDevice()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return md
+	}
+
+	v, err := tbl.MemoryErrorFor(device(0x1100))
+	me32, ok := v.(*MemoryError32)
+	if err != nil || !ok {
+		t.Fatalf("Type 18: got %T, %v", v, err)
+	}
+	if me32.ErrorType != 6 || me32.VendorSyndrome != 0x12345678 || me32.ArrayErrorAddress != nil ||
+		me32.DeviceErrorAddress == nil || *me32.DeviceErrorAddress != 0x1000 || me32.ErrorResolution != nil {
+		t.Errorf("Type 18: %+v", me32)
+	}
+
+	v, err = tbl.MemoryErrorFor(device(0x1101))
+	me64, ok := v.(*MemoryError64)
+	if err != nil || !ok {
+		t.Fatalf("Type 33: got %T, %v", v, err)
+	}
+	if me64.ArrayErrorAddress != nil || me64.DeviceErrorAddress == nil || *me64.DeviceErrorAddress != 1<<32 ||
+		me64.ErrorResolution == nil || *me64.ErrorResolution != 64 {
+		t.Errorf("Type 33: %+v", me64)
+	}
+
+	for _, h := range []uint16{0x1102, 0x1103} {
+		if v, err := tbl.MemoryErrorFor(device(h)); v != nil || err != nil {
+			t.Errorf("device 0x%04X without a record: got %v, %v", h, v, err)
+		}
+	}
+	if _, err := tbl.MemoryErrorFor(device(0x1104)); err == nil {
+		t.Error("a handle to a Type 17 should not resolve as a memory error")
+	}
+}
+
+func TestSplitRawSMBIOSData(t *testing.T) {
+	dmi := readFixture(t, "synthetic_sm3_dmi.bin")
+	raw := binary.LittleEndian.AppendUint32([]byte{0, 3, 2, 0}, uint32(len(dmi)))
+	raw = append(append(raw, dmi...), 0xAA, 0xBB) // trailing bytes past the reported length
+
+	entry, table, err := splitRawSMBIOSData(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(table, dmi) {
+		t.Error("table differs from the fixture")
+	}
+
+	tbl, err := Parse(bytes.NewReader(entry), bytes.NewReader(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := tbl.EntryPoint.Version(); v != "3.2" || tbl.EntryPoint.StructureTableMaxSize != uint32(len(dmi)) {
+		t.Errorf("entry point version %s, max size %d", v, tbl.EntryPoint.StructureTableMaxSize)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		raw      []byte
+		noSMBIOS bool
+	}{
+		{"short prologue", []byte{0, 3, 2, 0}, false},
+		{"empty table", []byte{0, 3, 2, 0, 0, 0, 0, 0}, true},
+		{"length past the buffer", []byte{0, 3, 2, 0, 8, 0, 0, 0, 127, 4}, false},
+	} {
+		_, _, err := splitRawSMBIOSData(tt.raw)
+		if err == nil || errors.Is(err, ErrNoSMBIOS) != tt.noSMBIOS {
+			t.Errorf("%s: got %v", tt.name, err)
+		}
+	}
+}
+
+func TestParseStructureErrors(t *testing.T) {
+	valid := rawStructure(1, 0x0100, make([]byte, 4), "a")
+	// withStrings encodes a structure holding n strings of size bytes each
+	withStrings := func(n, size int) []byte {
+		ss := make([]string, n)
+		for i := range ss {
+			ss[i] = string(bytes.Repeat([]byte{'x'}, size))
+		}
+		return rawStructure(2, 0x0200, []byte{1}, ss...)
+	}
+
+	tests := []struct {
+		name    string
+		raw     []byte // appended to a valid structure
+		want    []error
+		partial int
+	}{
+		{"clean end without end of table", nil, nil, 1},
+		{"length below the header", []byte{2, 3, 0x00, 0x02, 0, 0}, []error{ErrShortLength}, 1},
+		{"truncated header", []byte{2, 0x0F}, []error{ErrTruncatedHeader, ErrTruncated}, 1},
+		{"truncated formatted area", []byte{2, 0x0F, 0x00, 0x02, 1, 2, 3}, []error{ErrTruncatedData, ErrTruncated}, 1},
+		{"unterminated string", append([]byte{2, 5, 0x00, 0x02, 1}, "abc"...), []error{ErrStringTable, ErrTruncated}, 1},
+		{"single NUL terminator", []byte{2, 5, 0x00, 0x02, 1, 0, 'a', 0, 0}, []error{ErrStringTable}, 1},
+		{"too many buffered strings", withStrings(maxStrings+1, 1), []error{ErrStringTable}, 1},
+		{"too many streamed strings", withStrings(maxStrings+1, 20), []error{ErrStringTable}, 1},
+		{"string table too long", withStrings(100, 700), []error{ErrStringTable}, 1},
+		{"most strings allowed", withStrings(maxStrings, 20), nil, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Each case follows a valid structure, which must still be returned
+			raw := append(append([]byte{}, valid...), tt.raw...)
+			tbl, err := parseDmiTable(context.Background(), bytes.NewReader(raw))
+			if tt.want == nil && err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tt.want != nil && err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.want {
+				if !errors.Is(err, want) {
+					t.Errorf("error %q does not wrap %q", err, want)
+				}
+			}
+			if len(tbl.Structures) != tt.partial || tbl.Structures[0].Header.Handle != 0x0100 {
+				t.Errorf("got %d structures, want %d starting with handle 0x0100", len(tbl.Structures), tt.partial)
+			}
+		})
+	}
+}
+
+func TestParseContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	entry, dmi := readFixture(t, "synthetic_sm2_entry.bin"), readFixture(t, "synthetic_sm2_dmi.bin")
+	tbl, err := ParseContext(ctx, bytes.NewReader(entry), bytes.NewReader(dmi))
+	if !errors.Is(err, context.Canceled) || tbl != nil {
+		t.Errorf("ParseContext: got %v, %v, want context.Canceled and no table", tbl, err)
+	}
+
+	// Cancellation is checked between structures, the table parser stops after the first
+	tbl, err = parseDmiTable(ctx, bytes.NewReader(dmi))
+	if !errors.Is(err, context.Canceled) || len(tbl.Structures) != 1 {
+		t.Errorf("parseDmiTable: got %d structures, %v, want 1 and context.Canceled", len(tbl.Structures), err)
+	}
+}
+
+func BenchmarkParseDmiTable(b *testing.B) {
+	for _, tt := range fixtures {
+		raw, err := os.ReadFile(filepath.Join("testdata", tt.name+"_dmi.bin"))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(tt.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(raw)))
+			for i := 0; i < b.N; i++ {
+				if _, err := parseDmiTable(context.Background(), bytes.NewReader(raw)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func FuzzParseDmiTable(f *testing.F) {
+	for _, tt := range fixtures {
+		b, err := os.ReadFile(filepath.Join("testdata", tt.name+"_dmi.bin"))
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+	f.Add([]byte{0x7F, 0x04, 0x00, 0x00, 0x00, 0x00})
+	f.Add([]byte{0x01, 0x02, 0x00, 0x00})
+	f.Add([]byte{0x0B, 0x05, 0x00, 0x00, 0x01, 'a', 0x00})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			tbl, _ := parseDmiTable(context.Background(), bytes.NewReader(b))
+			if tbl == nil {
+				t.Error("parseDmiTable returned a nil table")
+			}
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("parseDmiTable did not return")
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+const (
+	SysfsEntryPoint = "/sys/firmware/dmi/tables/smbios_entry_point"
+	SysfsDMI        = "/sys/firmware/dmi/tables/DMI"
+)
+
+// ErrNoSMBIOS is wrapped by the error returned when the platform does not expose SMBIOS tables at all, as on many ARM
+// boards and some virtual machines
+var ErrNoSMBIOS = errors.New("no SMBIOS tables found, the platform likely does not provide SMBIOS")
+
+// ErrPermission is wrapped by the error returned when the tables exist but can not be read by the current user, most
+// distributions only let root read the sysfs DMI table and /dev/mem
+var ErrPermission = errors.New("permission denied reading the SMBIOS tables, try running as root")
+
+// Source provides the raw entry point and DMI table bytes for a platform
+type Source interface {
+	EntryPoint() ([]byte, error)
+	Table() ([]byte, error)
+}
+
+// ParseSource reads both tables from src and parses them, see Parse
+func ParseSource(src Source) (*SmTable, error) {
+	ep, err := src.EntryPoint()
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, err := src.Table()
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(ep), bytes.NewReader(tbl))
+}
+
+// OpenDefault opens the sysfs entry point and DMI table, ready for Parse. If either file can not be opened the error
+// names every missing file and nothing is left open. The caller closes both readers.
+func OpenDefault() (entry io.ReadCloser, dmi io.ReadCloser, err error) {
+	ef, entryErr := os.Open(SysfsEntryPoint)
+	df, dmiErr := os.Open(SysfsDMI)
+	entryErr, dmiErr = sysfsError(entryErr), sysfsError(dmiErr)
+	if err := errors.Join(entryErr, dmiErr); err != nil {
+		if ef != nil {
+			ef.Close()
+		}
+		if df != nil {
+			df.Close()
+		}
+		return nil, nil, err
+	}
+
+	return ef, df, nil
+}
+
+// sysfsError marks a missing sysfs table as the platform not providing SMBIOS
+func sysfsError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %w", ErrNoSMBIOS, err)
+	}
+
+	return permissionError(err)
+}
+
+// permissionError marks a permission failure as ErrPermission, once
+func permissionError(err error) error {
+	if errors.Is(err, fs.ErrPermission) && !errors.Is(err, ErrPermission) {
+		return fmt.Errorf("%w: %w", ErrPermission, err)
+	}
+
+	return err
+}
+
+// FileSource reads the tables from files laid out like sysfs, such as a saved dump
+type FileSource struct {
+	EntryPointPath string
+	TablePath      string
+}
+
+func (f FileSource) EntryPoint() ([]byte, error) {
+	b, err := os.ReadFile(f.EntryPointPath)
+	return b, permissionError(err)
+}
+
+func (f FileSource) Table() ([]byte, error) {
+	b, err := os.ReadFile(f.TablePath)
+	return b, permissionError(err)
+}
+
+// Length of the RawSMBIOSData prologue Windows puts ahead of the structure table
+const rawSMBIOSHeaderLen = 8
+
+// splitRawSMBIOSData splits a Windows RawSMBIOSData buffer into an entry point built from the version its prologue
+// carries and the structure table, trimmed to the length the prologue reports
+func splitRawSMBIOSData(buf []byte) (entry []byte, table []byte, err error) {
+	if len(buf) < rawSMBIOSHeaderLen {
+		return nil, nil, errors.New("RawSMBIOSData too short")
+	}
+	length := binary.LittleEndian.Uint32(buf[4:8])
+	if length == 0 {
+		return nil, nil, fmt.Errorf("%w: RawSMBIOSData is empty", ErrNoSMBIOS)
+	}
+	if uint64(length) > uint64(len(buf)-rawSMBIOSHeaderLen) {
+		return nil, nil, errors.New("RawSMBIOSData length exceeds the returned buffer")
+	}
+
+	// Used20CallingMethod, major, minor, DMI revision, then the dword table length
+	entry = entryPoint64(buf[1], buf[2], buf[3], length)
+
+	return entry, buf[rawSMBIOSHeaderLen : rawSMBIOSHeaderLen+int(length)], nil
+}
+
+// entryPoint64 builds a 3.0 entry point for platforms that only hand over the structure table, so the table can be
+// fed through the same parser
+func entryPoint64(major, minor, docrev uint8, tableLen uint32) []byte {
+	b := make([]byte, entryPoint64Len)
+	copy(b, anchor3)
+	b[6] = entryPoint64Len
+	b[7] = major
+	b[8] = minor
+	b[9] = docrev
+	b[10] = 0x01
+	binary.LittleEndian.PutUint32(b[12:16], tableLen)
+
+	// Checksum byte makes the sum of the entry point 0
+	var sum uint8
+	for _, c := range b {
+		sum += c
+	}
+	b[5] = -sum
+
+	return b
+}
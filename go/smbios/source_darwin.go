@@ -0,0 +1,101 @@
+package smbios
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// IORegSource reads the tables from the AppleSMBIOS service through ioreg, which publishes the structure table as
+// the SMBIOS property and the entry point as SMBIOS-EPS. ioreg is run once, on first use, so both methods return the
+// same snapshot.
+type IORegSource struct {
+	once  sync.Once
+	props map[string][]byte
+	err   error
+}
+
+// DefaultSource returns the standard source for the platform, the AppleSMBIOS registry entry
+func DefaultSource() Source {
+	return &IORegSource{}
+}
+
+func (r *IORegSource) EntryPoint() ([]byte, error) {
+	r.once.Do(func() { r.props, r.err = ioregSMBIOS() })
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	// Older releases do not publish the entry point, build one around the table instead
+	if ep, ok := r.props["SMBIOS-EPS"]; ok {
+		return ep, nil
+	}
+
+	return entryPoint64(2, 0, 0, uint32(len(r.props["SMBIOS"]))), nil
+}
+
+func (r *IORegSource) Table() ([]byte, error) {
+	r.once.Do(func() { r.props, r.err = ioregSMBIOS() })
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return r.props["SMBIOS"], nil
+}
+
+// ioregSMBIOS returns the data properties of the AppleSMBIOS service from the ioreg plist output
+func ioregSMBIOS() (map[string][]byte, error) {
+	out, err := exec.Command("ioreg", "-a", "-r", "-d", "1", "-c", "AppleSMBIOS").Output()
+	if err != nil {
+		return nil, fmt.Errorf("ioreg: %w", err)
+	}
+
+	// Walk the plist for <key>NAME</key><data>BASE64</data> pairs
+	props := map[string][]byte{}
+	dec := xml.NewDecoder(bytes.NewReader(out))
+	var key, elem string
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ioreg output: %w", err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			elem = tok.Name.Local
+		case xml.EndElement:
+			if tok.Name.Local != "key" {
+				key = ""
+			}
+			elem = ""
+		case xml.CharData:
+			switch elem {
+			case "key":
+				key = string(tok)
+			case "data":
+				if key == "SMBIOS" || key == "SMBIOS-EPS" {
+					b, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(tok)), ""))
+					if err != nil {
+						return nil, fmt.Errorf("ioreg %s: %w", key, err)
+					}
+					props[key] = b
+				}
+			}
+		}
+	}
+
+	if _, ok := props["SMBIOS"]; !ok {
+		return nil, fmt.Errorf("%w: AppleSMBIOS SMBIOS property not found", ErrNoSMBIOS)
+	}
+
+	return props, nil
+}
@@ -0,0 +1,78 @@
+package smbios
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// KenvSource reads the tables from /dev/mem, using the entry point address the loader publishes in the
+// hint.smbios.0.mem kernel environment variable. Requires root. Memory is read once, on first use, so both methods
+// return the same snapshot.
+type KenvSource struct {
+	Path string // memory device, /dev/mem when empty
+
+	once  sync.Once
+	entry []byte
+	table []byte
+	err   error
+}
+
+// DefaultSource returns the standard source for the platform, /dev/mem located through kenv
+func DefaultSource() Source {
+	return &KenvSource{}
+}
+
+func (k *KenvSource) EntryPoint() ([]byte, error) {
+	k.once.Do(func() { k.entry, k.table, k.err = k.read() })
+	return k.entry, k.err
+}
+
+func (k *KenvSource) Table() ([]byte, error) {
+	k.once.Do(func() { k.entry, k.table, k.err = k.read() })
+	return k.table, k.err
+}
+
+func (k *KenvSource) read() ([]byte, []byte, error) {
+	out, err := exec.Command("kenv", "-q", "hint.smbios.0.mem").Output()
+	if err != nil {
+		// kenv -q fails quietly when the loader did not find an entry point
+		return nil, nil, fmt.Errorf("%w: kenv hint.smbios.0.mem: %w", ErrNoSMBIOS, err)
+	}
+
+	addr, err := strconv.ParseInt(strings.TrimSpace(string(out)), 0, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid hint.smbios.0.mem %q", strings.TrimSpace(string(out)))
+	}
+
+	path := k.Path
+	if path == "" {
+		path = "/dev/mem"
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, permissionError(err)
+	}
+	defer f.Close()
+
+	// Enough for either entry point, it is trimmed to the length it reports
+	b := make([]byte, 0x20)
+	if _, err := f.ReadAt(b, addr); err != nil {
+		return nil, nil, fmt.Errorf("unable to read entry point: %w", err)
+	}
+
+	entry, err := findEntryPoint(b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: anchor not found at hint.smbios.0.mem", ErrNoSMBIOS)
+	}
+
+	table, err := readMemTable(f, entry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entry, table, nil
+}
@@ -0,0 +1,21 @@
+package smbios
+
+// DefaultSource returns the standard source for the platform, the sysfs tables
+func DefaultSource() Source {
+	return sysfsSource{FileSource{EntryPointPath: SysfsEntryPoint, TablePath: SysfsDMI}}
+}
+
+// sysfsSource is a FileSource that reports missing tables as ErrNoSMBIOS
+type sysfsSource struct {
+	FileSource
+}
+
+func (s sysfsSource) EntryPoint() ([]byte, error) {
+	b, err := s.FileSource.EntryPoint()
+	return b, sysfsError(err)
+}
+
+func (s sysfsSource) Table() ([]byte, error) {
+	b, err := s.FileSource.Table()
+	return b, sysfsError(err)
+}
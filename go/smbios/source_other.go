@@ -0,0 +1,23 @@
+//go:build !linux && !windows && !freebsd && !darwin
+
+package smbios
+
+import (
+	"errors"
+	"runtime"
+)
+
+// DefaultSource returns a source that fails, there is no known way to read the tables on this platform
+func DefaultSource() Source {
+	return unsupportedSource{}
+}
+
+type unsupportedSource struct{}
+
+func (unsupportedSource) EntryPoint() ([]byte, error) {
+	return nil, errors.New("reading SMBIOS is not supported on " + runtime.GOOS)
+}
+
+func (unsupportedSource) Table() ([]byte, error) {
+	return nil, errors.New("reading SMBIOS is not supported on " + runtime.GOOS)
+}
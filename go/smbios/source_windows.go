@@ -0,0 +1,66 @@
+package smbios
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Firmware table provider signature 'RSMB'
+const rsmb = 'R'<<24 | 'S'<<16 | 'M'<<8 | 'B'
+
+var procGetSystemFirmwareTable = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetSystemFirmwareTable")
+
+// FirmwareTableSource reads the tables with GetSystemFirmwareTable. Windows only returns the structure table behind
+// an 8 byte RawSMBIOSData prologue, the entry point is built from the version that prologue carries. The firmware
+// table is fetched once, on first use, so both methods return the same snapshot.
+type FirmwareTableSource struct {
+	once  sync.Once
+	entry []byte
+	table []byte
+	err   error
+}
+
+// DefaultSource returns the standard source for the platform, the RSMB firmware table
+func DefaultSource() Source {
+	return &FirmwareTableSource{}
+}
+
+func (f *FirmwareTableSource) EntryPoint() ([]byte, error) {
+	f.once.Do(f.read)
+	return f.entry, f.err
+}
+
+func (f *FirmwareTableSource) Table() ([]byte, error) {
+	f.once.Do(f.read)
+	return f.table, f.err
+}
+
+func (f *FirmwareTableSource) read() {
+	raw, err := firmwareTable()
+	if err != nil {
+		f.err = err
+		return
+	}
+
+	f.entry, f.table, f.err = splitRawSMBIOSData(raw)
+}
+
+// firmwareTable returns the RSMB firmware table, a RawSMBIOSData buffer
+func firmwareTable() ([]byte, error) {
+	// The first call with no buffer returns the size needed
+	n, _, err := procGetSystemFirmwareTable.Call(rsmb, 0, 0, 0)
+	if n == 0 {
+		return nil, fmt.Errorf("GetSystemFirmwareTable: %w", err)
+	}
+
+	buf := make([]byte, n)
+	got, _, err := procGetSystemFirmwareTable.Call(rsmb, 0, uintptr(unsafe.Pointer(&buf[0])), n)
+	if got == 0 || got > n {
+		return nil, fmt.Errorf("GetSystemFirmwareTable: %w", err)
+	}
+
+	return buf[:got], nil
+}
@@ -0,0 +1,82 @@
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// SystemInformation is the decoded Type 1 structure
+type SystemInformation struct {
+	Manufacturer string     `json:"manufacturer"`
+	ProductName  string     `json:"product_name"`
+	Version      string     `json:"version"`
+	SerialNumber string     `json:"serial_number"`
+	UUID         string     `json:"uuid"`         // 2.1+, empty when not present or not settable
+	WakeUpType   WakeUpType `json:"wake_up_type"` // 2.1+
+	SKUNumber    string     `json:"sku_number"`   // 2.4+
+	Family       string     `json:"family"`       // 2.4+
+}
+
+// WakeUpType is the event that last powered on the system. It renders as its spec name, in JSON as well.
+type WakeUpType uint8
+
+var wakeUpTypeNames = map[WakeUpType]string{
+	0: "Reserved",
+	1: "Other",
+	2: "Unknown",
+	3: "APM Timer",
+	4: "Modem Ring",
+	5: "LAN Remote",
+	6: "Power Switch",
+	7: "PCI PME#",
+	8: "AC Power Restored",
+}
+
+func (w WakeUpType) String() string {
+	return enumName(wakeUpTypeNames, w)
+}
+
+func (w WakeUpType) MarshalText() ([]byte, error) {
+	return []byte(w.String()), nil
+}
+
+// System decodes a Type 1 (System Information) structure
+func (s Structure) System() (*SystemInformation, error) {
+	if err := s.check(1, 0x08); err != nil {
+		return nil, err
+	}
+
+	si := SystemInformation{
+		Manufacturer: s.stringAt(0x04),
+		ProductName:  s.stringAt(0x05),
+		Version:      s.stringAt(0x06),
+		SerialNumber: s.stringAt(0x07),
+		SKUNumber:    s.stringAt(0x19),
+		Family:       s.stringAt(0x1A),
+	}
+
+	if uuid, ok := s.field(0x08, 16); ok {
+		si.UUID = formatUUID(uuid)
+	}
+	wake, _ := s.byteAt(0x18)
+	si.WakeUpType = WakeUpType(wake)
+
+	return &si, nil
+}
+
+// formatUUID renders the 16 byte UUID in the canonical dashed form. The first three fields are stored little endian
+// per the spec. All 0x00 (not present) and all 0xFF (not settable) return an empty string.
+func formatUUID(b []byte) string {
+	if bytes.Equal(b, make([]byte, 16)) || bytes.Equal(b, bytes.Repeat([]byte{0xFF}, 16)) {
+		return ""
+	}
+
+	return fmt.Sprintf("%08X-%04X-%04X-%X-%X",
+		binary.LittleEndian.Uint32(b[0:4]),
+		binary.LittleEndian.Uint16(b[4:6]),
+		binary.LittleEndian.Uint16(b[6:8]),
+		b[8:10],
+		b[10:16],
+	)
+}
@@ -0,0 +1,418 @@
+package smbios
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	endOfTable = 127
+
+	// String references are a single byte so a structure can not use more than 255 strings
+	maxStrings        = 255
+	maxStringTableLen = 0xFFFF
+
+	// Formatted areas are carved out of blocks of this size to save an allocation per structure
+	arenaLen = 4096
+)
+
+var terminater = []byte{0x00, 0x00}
+
+// Errors wrapped by the table parser, along with the underlying read error. ErrTruncatedHeader and ErrTruncatedData
+// both wrap ErrTruncated, a string table that ends early wraps both ErrStringTable and ErrTruncated.
+var (
+	// ErrTruncated is wrapped by the error returned when the table ends in the middle of a structure
+	ErrTruncated = errors.New("dmi table truncated")
+
+	// ErrTruncatedHeader is returned when the table ends part way through a structure header
+	ErrTruncatedHeader = fmt.Errorf("%w in a structure header", ErrTruncated)
+
+	// ErrTruncatedData is returned when the table ends part way through a formatted area
+	ErrTruncatedData = fmt.Errorf("%w in a formatted area", ErrTruncated)
+
+	// ErrStringTable is returned when a string table is malformed or ends early
+	ErrStringTable = errors.New("invalid string table")
+
+	// ErrShortLength is returned when a structure length is below the header size, the table is corrupt
+	ErrShortLength = fmt.Errorf("structure length is shorter than the %d byte header", headerLen)
+)
+
+// parseDmiTable reads structures until the end-of-table structure, the end of the data or ctx is done. On an error
+// the structures decoded so far are returned along with it.
+func parseDmiTable(ctx context.Context, r io.Reader) (*SmTable, error) {
+	t := SmTable{Structures: []Structure{}}
+	err := ParseStructures(r, func(s Structure) error {
+		t.Structures = append(t.Structures, s)
+		return ctx.Err()
+	})
+
+	return &t, err
+}
+
+// ParseTableBytes parses a DMI table already in memory, such as one from the Windows firmware table API. There is no
+// entry point so EntryPoint is nil, errors are as for Parse.
+func ParseTableBytes(b []byte) (*SmTable, error) {
+	return parseDmiTable(context.Background(), bytes.NewReader(b))
+}
+
+// ParseStructures reads the DMI table and calls fn for each structure as it is parsed, without holding the whole
+// table. Parsing stops at the end-of-table structure, the end of the data, or when fn returns an error, which is
+// returned as is.
+func ParseStructures(r io.Reader, fn func(Structure) error) error {
+	br := bufio.NewReader(r)
+	var hdr [headerLen]byte
+	var arena []byte
+
+	for {
+		// A clean end of data is only possible on a structure boundary, a partial header is a truncated table
+		if _, err := io.ReadFull(br, hdr[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return fmt.Errorf("%w: %w", ErrTruncatedHeader, err)
+			}
+			return fmt.Errorf("structure header: %w", err)
+		}
+
+		h := Header{
+			Type:   hdr[0],
+			Length: hdr[1],
+			Handle: binary.LittleEndian.Uint16(hdr[2:4]),
+		}
+
+		// A length below the header size would underflow, the table is corrupt
+		if h.Length < headerLen {
+			return fmt.Errorf("structure 0x%04X: %w: length %d", h.Handle, ErrShortLength, h.Length)
+		}
+		length := int(h.Length - headerLen)
+
+		// Each formatted area is capped so appending to one can not overwrite the next
+		if len(arena) < length {
+			arena = make([]byte, arenaLen)
+		}
+		buf := arena[:length:length]
+		arena = arena[length:]
+		if _, err := io.ReadFull(br, buf); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return fmt.Errorf("structure 0x%04X: %w: %w", h.Handle, ErrTruncatedData, err)
+			}
+			return fmt.Errorf("structure 0x%04X formatted area: %w", h.Handle, err)
+		}
+
+		s := Structure{
+			Header:     h,
+			Formatterd: buf,
+		}
+
+		strs, err := readStrings(br, h.Handle)
+		if err != nil {
+			return err
+		}
+		s.Strings = strs
+
+		if err := fn(s); err != nil {
+			return err
+		}
+
+		// The end-of-table structure is the last one, anything after it is padding
+		if h.Type == endOfTable {
+			break
+		}
+	}
+
+	return nil
+}
+
+// CheckAgainst compares the parsed structures with what the entry point advertises. A structure larger than the
+// 2.x MaxStructureSize, or a count that differs from NumberStructures, usually means the parser desynced on a bad
+// length byte. The 3.0 entry point has neither field so there is nothing to compare.
+func (t *SmTable) CheckAgainst(ep *EntryPoint) error {
+	if ep == nil {
+		return nil
+	}
+
+	var errs []error
+	if ep.MaxStructureSize != 0 {
+		for _, s := range t.Structures {
+			if n := s.size(); n > int(ep.MaxStructureSize) {
+				errs = append(errs, fmt.Errorf("structure 0x%04X is %d bytes, more than the %d byte maximum in the entry point",
+					s.Header.Handle, n, ep.MaxStructureSize))
+				break
+			}
+		}
+	}
+
+	if ep.NumberStructures != 0 && int(ep.NumberStructures) != len(t.Structures) {
+		errs = append(errs, fmt.Errorf("entry point lists %d structures, table has %d", ep.NumberStructures,
+			len(t.Structures)))
+	}
+
+	return errors.Join(errs...)
+}
+
+// size returns the length of the structure in the table, formatted area and string table included
+func (s Structure) size() int {
+	n := headerLen + len(s.Formatterd)
+	if len(s.Strings) == 0 {
+		return n + len(terminater)
+	}
+	for _, v := range s.Strings {
+		n += len(v) + 1
+	}
+
+	return n + 1
+}
+
+// readStrings reads the string table following a formatted area, consuming it and nothing else. A structure without
+// strings is a bare double NUL, otherwise each string is NUL terminated and one more NUL closes the table.
+func readStrings(br *bufio.Reader, handle uint16) ([]string, error) {
+	term, err := br.Peek(2)
+	if err != nil {
+		return nil, truncatedStrings(handle, err)
+	}
+	if bytes.Equal(term, terminater) {
+		br.Discard(2)
+		return []string{}, nil
+	}
+	if term[0] == 0x00 {
+		// A single NUL is neither a string nor the double NUL terminator
+		return nil, fmt.Errorf("structure 0x%04X: %w: single NUL terminator", handle, ErrStringTable)
+	}
+
+	// When the whole table is already buffered it is converted with a single allocation and sliced into the strings,
+	// otherwise it is read a string at a time
+	buffered, _ := br.Peek(br.Buffered())
+	if end := bytes.Index(buffered, terminater); end >= 0 {
+		n := bytes.Count(buffered[:end], terminater[:1]) + 1
+		if n > maxStrings || end > maxStringTableLen {
+			return nil, fmt.Errorf("structure 0x%04X: %w: not terminated after %d strings", handle, ErrStringTable, maxStrings)
+		}
+
+		table := string(buffered[:end])
+		ss := make([]string, 0, n)
+		for i := strings.IndexByte(table, 0x00); i >= 0; i = strings.IndexByte(table, 0x00) {
+			ss = append(ss, table[:i])
+			table = table[i+1:]
+		}
+		ss = append(ss, table)
+		br.Discard(end + len(terminater))
+
+		return ss, nil
+	}
+
+	// Bound the string table so a blob that never terminates it fails instead of consuming the input
+	ss := []string{}
+	tableLen := 0
+	for {
+		if len(ss) >= maxStrings || tableLen > maxStringTableLen {
+			return nil, fmt.Errorf("structure 0x%04X: %w: not terminated after %d strings", handle, ErrStringTable, len(ss))
+		}
+
+		// ReadSlice avoids a copy, a string longer than the buffer falls back to ReadBytes
+		raw, err := br.ReadSlice(0x00)
+		if errors.Is(err, bufio.ErrBufferFull) {
+			head := append([]byte{}, raw...)
+			raw, err = br.ReadBytes(0x00)
+			raw = append(head, raw...)
+		}
+		if err != nil {
+			return nil, truncatedStrings(handle, err)
+		}
+		tableLen += len(raw)
+		ss = append(ss, string(raw[:len(raw)-1]))
+
+		next, err := br.Peek(1)
+		if err != nil {
+			return nil, truncatedStrings(handle, err)
+		}
+		if next[0] == 0x00 {
+			br.Discard(1)
+			return ss, nil
+		}
+	}
+}
+
+// truncatedStrings wraps a read error hit inside a string table
+func truncatedStrings(h uint16, err error) error {
+	return fmt.Errorf("structure 0x%04X: %w: %w: %w", h, ErrStringTable, ErrTruncated, err)
+}
+
+// ByHandle returns the structure with the given handle. The handle index is built once on the first lookup, so
+// lookups are safe for concurrent use. SortByTypeHandle and FilterObsolete reset it. Changing Structures directly
+// after the first lookup leaves the index stale, an indexed handle that has moved is found by scanning but an added one
+// is not.
+func (t *SmTable) ByHandle(h uint16) (*Structure, bool) {
+	t.handlesOnce.Do(t.indexHandles)
+
+	i, ok := t.handles[h]
+	if !ok {
+		return nil, false
+	}
+	if i < len(t.Structures) && t.Structures[i].Header.Handle == h {
+		return &t.Structures[i], true
+	}
+
+	for i := range t.Structures {
+		if t.Structures[i].Header.Handle == h {
+			return &t.Structures[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// indexHandles maps each handle to its position in Structures
+func (t *SmTable) indexHandles() {
+	t.handles = make(map[uint16]int, len(t.Structures))
+	for i, s := range t.Structures {
+		// Keep the first structure if firmware repeats a handle
+		if _, ok := t.handles[s.Header.Handle]; !ok {
+			t.handles[s.Header.Handle] = i
+		}
+	}
+}
+
+// resetHandles drops the handle index after Structures is reordered or shrunk, the next lookup rebuilds it
+func (t *SmTable) resetHandles() {
+	t.handlesOnce = sync.Once{}
+	t.handles = nil
+}
+
+// ByType returns every structure of the given type in table order. The slice is empty, not nil, when none match.
+func (t *SmTable) ByType(typ uint8) []Structure {
+	ss := []Structure{}
+	for _, s := range t.Structures {
+		if s.Header.Type == typ {
+			ss = append(ss, s)
+		}
+	}
+
+	return ss
+}
+
+// SortByTypeHandle orders the structures by type, then by handle, so tables from firmware that lists them in a
+// different order compare equal. Structures sharing a type and handle keep their order.
+func (t *SmTable) SortByTypeHandle() {
+	sort.SliceStable(t.Structures, func(i, j int) bool {
+		a, b := t.Structures[i].Header, t.Structures[j].Header
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Handle < b.Handle
+	})
+	t.resetHandles()
+}
+
+// FilterObsolete drops the structure types the specification marks obsolete (5, 6 and 10) from the table
+func (t *SmTable) FilterObsolete() {
+	ss := t.Structures[:0]
+	for _, s := range t.Structures {
+		if !obsoleteTypes[s.Header.Type] {
+			ss = append(ss, s)
+		}
+	}
+	t.Structures = ss
+	t.resetHandles()
+}
+
+// TypeCounts returns the number of structures of each type present in the table
+func (t *SmTable) TypeCounts() map[uint8]int {
+	counts := map[uint8]int{}
+	for _, s := range t.Structures {
+		counts[s.Header.Type]++
+	}
+
+	return counts
+}
+
+// WriteTo writes the structures back out in the binary DMI table format, ending with an end-of-table structure if
+// the table does not already have one. Parsing the output gives back an equivalent table.
+func (t *SmTable) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	var maxHandle uint16
+	used := map[uint16]bool{}
+	hasEnd := false
+
+	for _, s := range t.Structures {
+		b, err := s.marshalBinary()
+		if err != nil {
+			return n, err
+		}
+
+		m, err := w.Write(b)
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+
+		if s.Header.Handle > maxHandle {
+			maxHandle = s.Header.Handle
+		}
+		used[s.Header.Handle] = true
+		hasEnd = s.Header.Type == endOfTable
+	}
+
+	if !hasEnd {
+		end := Structure{Header: Header{Type: endOfTable, Length: headerLen, Handle: unusedHandle(used, maxHandle)}}
+		b, _ := end.marshalBinary()
+		m, err := w.Write(b)
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+// unusedHandle returns the handle after the highest one, or the lowest free handle when that would land on the
+// reserved 0xFFFE and 0xFFFF. A table that somehow uses every other handle gets 0xFFFE.
+func unusedHandle(used map[uint16]bool, maxHandle uint16) uint16 {
+	if maxHandle < 0xFFFD {
+		return maxHandle + 1
+	}
+	for h := uint16(0); h < 0xFFFE; h++ {
+		if !used[h] {
+			return h
+		}
+	}
+
+	return 0xFFFE
+}
+
+// marshalBinary encodes the header, formatted area and string table of a single structure
+func (s Structure) marshalBinary() ([]byte, error) {
+	length := headerLen + len(s.Formatterd)
+	if length > 0xFF {
+		return nil, fmt.Errorf("structure 0x%04X: formatted area too long (%d bytes)", s.Header.Handle, len(s.Formatterd))
+	}
+
+	b := make([]byte, headerLen, length+2)
+	b[0] = s.Header.Type
+	b[1] = uint8(length)
+	binary.LittleEndian.PutUint16(b[2:4], s.Header.Handle)
+	b = append(b, s.Formatterd...)
+
+	// Strings are NUL terminated with an extra NUL closing the table, no strings is still a double NUL
+	if len(s.Strings) == 0 {
+		return append(b, terminater...), nil
+	}
+	for _, v := range s.Strings {
+		if v == "" || strings.IndexByte(v, 0x00) >= 0 {
+			return nil, fmt.Errorf("structure 0x%04X: string %q cannot be encoded", s.Header.Handle, v)
+		}
+		b = append(b, v...)
+		b = append(b, 0x00)
+	}
+
+	return append(b, 0x00), nil
+}
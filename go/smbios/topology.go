@@ -0,0 +1,115 @@
+package smbios
+
+import (
+	"sort"
+)
+
+// MemoryTopology groups the memory devices under their physical arrays, with the address ranges each array and
+// device is mapped to
+type MemoryTopology struct {
+	Arrays     []MemoryArrayTopology  `json:"arrays"`
+	Unassigned []MemoryDeviceTopology `json:"unassigned"` // devices whose array handle matches no Type 16 structure
+}
+
+// MemoryArrayTopology is a Type 16 array with its Type 17 devices and Type 19 address ranges
+type MemoryArrayTopology struct {
+	Handle  uint16                 `json:"handle"`
+	Array   PhysicalMemoryArray    `json:"array"`
+	Devices []MemoryDeviceTopology `json:"devices"`
+	Ranges  []MemoryRangeTopology  `json:"ranges"`
+}
+
+// MemoryDeviceTopology is a Type 17 device with the Type 20 ranges mapped to it
+type MemoryDeviceTopology struct {
+	Handle   uint16                      `json:"handle"`
+	Device   MemoryDevice                `json:"device"`
+	Mappings []MemoryDeviceMappedAddress `json:"mappings"`
+}
+
+// MemoryRangeTopology is a Type 19 array range and the devices that back it. More than one device means the range is
+// interleaved across them, the device mappings give each one's position.
+type MemoryRangeTopology struct {
+	Handle  uint16                   `json:"handle"`
+	Range   MemoryArrayMappedAddress `json:"range"`
+	Devices []uint16                 `json:"devices"` // Type 17 handles in interleave position order
+}
+
+// MemoryTopology links the Type 16, 17, 19 and 20 structures by handle
+func (t *SmTable) MemoryTopology() (*MemoryTopology, error) {
+	mt := MemoryTopology{
+		Arrays:     []MemoryArrayTopology{},
+		Unassigned: []MemoryDeviceTopology{},
+	}
+
+	arrays := map[uint16]int{}
+	for _, s := range t.ByType(16) {
+		pa, err := s.PhysicalMemoryArray()
+		if err != nil {
+			return nil, err
+		}
+		arrays[s.Header.Handle] = len(mt.Arrays)
+		mt.Arrays = append(mt.Arrays, MemoryArrayTopology{
+			Handle:  s.Header.Handle,
+			Array:   *pa,
+			Devices: []MemoryDeviceTopology{},
+			Ranges:  []MemoryRangeTopology{},
+		})
+	}
+
+	// Device mappings by device handle, and device handles by the array range they map into
+	mappings := map[uint16][]MemoryDeviceMappedAddress{}
+	backing := map[uint16][]MemoryDeviceMappedAddress{}
+	for _, s := range t.ByType(20) {
+		md, err := s.MemoryDeviceMappedAddress()
+		if err != nil {
+			return nil, err
+		}
+		mappings[md.MemoryDeviceHandle] = append(mappings[md.MemoryDeviceHandle], *md)
+		backing[md.MemoryArrayMappedAddrHandle] = append(backing[md.MemoryArrayMappedAddrHandle], *md)
+	}
+
+	for _, s := range t.ByType(19) {
+		ma, err := s.MemoryArrayMappedAddress()
+		if err != nil {
+			return nil, err
+		}
+		i, ok := arrays[ma.MemoryArrayHandle]
+		if !ok {
+			continue
+		}
+
+		r := MemoryRangeTopology{Handle: s.Header.Handle, Range: *ma, Devices: []uint16{}}
+		devs := backing[s.Header.Handle]
+		sortByInterleave(devs)
+		for _, md := range devs {
+			r.Devices = append(r.Devices, md.MemoryDeviceHandle)
+		}
+		mt.Arrays[i].Ranges = append(mt.Arrays[i].Ranges, r)
+	}
+
+	for _, s := range t.ByType(17) {
+		md, err := s.MemoryDevice()
+		if err != nil {
+			return nil, err
+		}
+
+		dt := MemoryDeviceTopology{Handle: s.Header.Handle, Device: *md, Mappings: mappings[s.Header.Handle]}
+		if dt.Mappings == nil {
+			dt.Mappings = []MemoryDeviceMappedAddress{}
+		}
+		if i, ok := arrays[md.PhysicalArrayHandle]; ok {
+			mt.Arrays[i].Devices = append(mt.Arrays[i].Devices, dt)
+		} else {
+			mt.Unassigned = append(mt.Unassigned, dt)
+		}
+	}
+
+	return &mt, nil
+}
+
+// sortByInterleave orders device mappings by interleave position, unknown positions last
+func sortByInterleave(mds []MemoryDeviceMappedAddress) {
+	sort.SliceStable(mds, func(i, j int) bool {
+		return mds[i].InterleavePosition < mds[j].InterleavePosition
+	})
+}
@@ -0,0 +1,53 @@
+package smbios
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// TPMDevice is the decoded Type 43 structure
+type TPMDevice struct {
+	VendorID         string `json:"vendor_id"` // ASCII when printable, otherwise hex
+	MajorSpecVersion uint8  `json:"major_spec_version"`
+	MinorSpecVersion uint8  `json:"minor_spec_version"`
+	TPM2             bool   `json:"tpm2"`              // false for a TPM 1.2 device
+	FirmwareVersion1 uint32 `json:"firmware_version1"` // TPM 2.0 holds the upper 32 bits here
+	FirmwareVersion2 uint32 `json:"firmware_version2"` // TPM 2.0 holds the lower 32 bits here
+	Description      string `json:"description"`
+	Characteristics  uint64 `json:"characteristics"`
+	OEMDefined       uint32 `json:"oem_defined"`
+}
+
+// TPM decodes a Type 43 (TPM Device) structure
+func (s Structure) TPM() (*TPMDevice, error) {
+	if err := s.check(43, 0x1F); err != nil {
+		return nil, err
+	}
+
+	vendor, _ := s.field(0x04, 4)
+	tpm := TPMDevice{
+		VendorID:    formatVendorID(vendor),
+		Description: s.stringAt(0x12),
+	}
+	tpm.MajorSpecVersion, _ = s.byteAt(0x08)
+	tpm.MinorSpecVersion, _ = s.byteAt(0x09)
+	tpm.TPM2 = tpm.MajorSpecVersion == 2
+	tpm.FirmwareVersion1, _ = s.dword(0x0A)
+	tpm.FirmwareVersion2, _ = s.dword(0x0E)
+	tpm.Characteristics, _ = s.qword(0x13)
+	tpm.OEMDefined, _ = s.dword(0x1B)
+
+	return &tpm, nil
+}
+
+// formatVendorID renders the 4 byte vendor ID as ASCII, trailing NULs trimmed, or as hex when it is not printable
+func formatVendorID(b []byte) string {
+	id := bytes.TrimRight(b, "\x00")
+	for _, c := range id {
+		if c < 0x20 || c > 0x7E {
+			return fmt.Sprintf("0x%X", b)
+		}
+	}
+
+	return string(id)
+}
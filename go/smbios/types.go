@@ -0,0 +1,122 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// Structure type names from DSP0134 3.2.0
+var typeNames = map[uint8]string{
+	0:   "BIOS Information",
+	1:   "System Information",
+	2:   "Baseboard Information",
+	3:   "System Enclosure or Chassis",
+	4:   "Processor Information",
+	5:   "Memory Controller Information",
+	6:   "Memory Module Information",
+	7:   "Cache Information",
+	8:   "Port Connector Information",
+	9:   "System Slots",
+	10:  "On Board Devices Information",
+	11:  "OEM Strings",
+	12:  "System Configuration Options",
+	13:  "BIOS Language Information",
+	14:  "Group Associations",
+	15:  "System Event Log",
+	16:  "Physical Memory Array",
+	17:  "Memory Device",
+	18:  "32-Bit Memory Error Information",
+	19:  "Memory Array Mapped Address",
+	20:  "Memory Device Mapped Address",
+	21:  "Built-in Pointing Device",
+	22:  "Portable Battery",
+	23:  "System Reset",
+	24:  "Hardware Security",
+	25:  "System Power Controls",
+	26:  "Voltage Probe",
+	27:  "Cooling Device",
+	28:  "Temperature Probe",
+	29:  "Electrical Current Probe",
+	30:  "Out-of-Band Remote Access",
+	31:  "Boot Integrity Services (BIS) Entry Point",
+	32:  "System Boot Information",
+	33:  "64-Bit Memory Error Information",
+	34:  "Management Device",
+	35:  "Management Device Component",
+	36:  "Management Device Threshold Data",
+	37:  "Memory Channel",
+	38:  "IPMI Device Information",
+	39:  "System Power Supply",
+	40:  "Additional Information",
+	41:  "Onboard Devices Extended Information",
+	42:  "Management Controller Host Interface",
+	43:  "TPM Device",
+	126: "Inactive",
+	127: "End-of-Table",
+}
+
+// Offset of the string that labels a structure of each type, such as the socket of a processor or the locator of a
+// memory device
+var nameOffsets = map[uint8]int{
+	0:  0x04, // vendor
+	1:  0x05, // product name
+	2:  0x05, // product
+	3:  0x04, // manufacturer
+	4:  0x04, // socket designation
+	7:  0x04, // socket designation
+	8:  0x04, // internal reference designator
+	9:  0x04, // slot designation
+	14: 0x04, // group name
+	17: 0x10, // device locator
+	22: 0x08, // device name
+	26: 0x04, // description
+	27: 0x0E, // description
+	28: 0x04, // description
+	29: 0x04, // description
+	30: 0x04, // manufacturer name
+	34: 0x04, // description
+	35: 0x04, // description
+	39: 0x06, // device name
+	41: 0x04, // reference designation
+	43: 0x12, // description
+}
+
+// Structure types DSP0134 3.2.0 marks obsolete
+var obsoleteTypes = map[uint8]bool{
+	5:  true, // Memory Controller Information
+	6:  true, // Memory Module Information
+	10: true, // On Board Devices Information, replaced by Type 41
+}
+
+// enumName returns the spec name of an enumerated field value, "Unknown (0xNN)" when the value has none
+func enumName[T ~uint8 | ~uint16](names map[T]string, v T) string {
+	if name, ok := names[v]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Unknown (0x%02X)", uint16(v))
+}
+
+// TypeName returns the spec name of the structure type, "OEM-specific" for types 128 through 255 and "Unknown" for
+// anything else
+func (h Header) TypeName() string {
+	if name, ok := typeNames[h.Type]; ok {
+		return name
+	}
+
+	if h.Type >= 128 {
+		return "OEM-specific"
+	}
+
+	return "Unknown"
+}
+
+// Name returns the string that conventionally labels the structure, such as the socket designation of a processor
+// or the device locator of a memory device. It is empty for types without such a string or when it is not set.
+func (s Structure) Name() string {
+	offset, ok := nameOffsets[s.Header.Type]
+	if !ok {
+		return ""
+	}
+
+	return s.stringAt(offset)
+}
@@ -0,0 +1,57 @@
+package smbios
+
+import (
+	"errors"
+	"fmt"
+)
+
+// String fields read by the decoders, by structure type and offset. Fields past the end of an older, shorter
+// structure are skipped.
+var stringOffsets = map[uint8][]int{
+	0:  {0x04, 0x05, 0x08},
+	1:  {0x04, 0x05, 0x06, 0x07, 0x19, 0x1A},
+	2:  {0x04, 0x05, 0x06, 0x07, 0x08, 0x0A},
+	3:  {0x04, 0x06, 0x07, 0x08},
+	4:  {0x04, 0x07, 0x10, 0x20, 0x21, 0x22},
+	7:  {0x04},
+	8:  {0x04, 0x06},
+	9:  {0x04},
+	13: {0x15},
+	14: {0x04},
+	17: {0x10, 0x11, 0x17, 0x18, 0x19, 0x1A},
+	22: {0x04, 0x05, 0x06, 0x07, 0x08, 0x0E, 0x14},
+	26: {0x04},
+	27: {0x0E},
+	28: {0x04},
+	29: {0x04},
+	30: {0x04},
+	34: {0x04},
+	35: {0x04},
+	39: {0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B},
+	41: {0x04},
+	43: {0x12},
+}
+
+// Validate checks the structure is self consistent: the header length covers the header, the formatted area matches
+// the header length and every string field a decoder reads refers to a string that is present. All problems found
+// are returned joined.
+func (s Structure) Validate() error {
+	var errs []error
+
+	if s.Header.Length < headerLen {
+		errs = append(errs, fmt.Errorf("length %d is shorter than the %d byte header", s.Header.Length, headerLen))
+	} else if len(s.Formatterd) != int(s.Header.Length)-headerLen {
+		errs = append(errs, fmt.Errorf("formatted area is %d bytes, header length %d needs %d", len(s.Formatterd),
+			s.Header.Length, int(s.Header.Length)-headerLen))
+	}
+
+	for _, offset := range stringOffsets[s.Header.Type] {
+		ref, ok := s.byteAt(offset)
+		if ok && int(ref) > len(s.Strings) {
+			errs = append(errs, fmt.Errorf("string at offset 0x%02X refers to string %d, %d present", offset, ref,
+				len(s.Strings)))
+		}
+	}
+
+	return errors.Join(errs...)
+}
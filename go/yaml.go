@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/rrdr20/smbtest/smbios"
+	"gopkg.in/yaml.v3"
+)
+
+// renderYAML writes the table as YAML. The table is encoded as JSON first so the field names, encodings and decoded
+// structures match the JSON output, then re-emitted in block style.
+func renderYAML(w io.Writer, t *smbios.SmTable) error {
+	b, err := json.Marshal(decodedTable(t))
+	if err != nil {
+		return err
+	}
+
+	var n yaml.Node
+	if err := yaml.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	blockStyle(&n)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&n); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+// blockStyle drops the flow and quoting styles carried over from JSON, the encoder still quotes strings that would
+// otherwise read back as another type
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}